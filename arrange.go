@@ -0,0 +1,187 @@
+package colprint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// filterOperators are the operators of filter expressions. Operators starting with another operator come first.
+var filterOperators = []string{"!=", "<=", ">=", "=", "<", ">", "~"}
+
+// filter is a parsed filter expression, matching the rows whose value in the column labeled label compares to
+// value as given by op.
+type filter struct {
+	label string
+	op    string
+	value string
+	re    *regexp.Regexp
+}
+
+// parseFilter parses a filter expression of the form LABEL OP VALUE, e.g. "Age>=30" or "Name~^web".
+func parseFilter(expr string) (filter, error) {
+	for i := range expr {
+		for _, op := range filterOperators {
+			if !strings.HasPrefix(expr[i:], op) {
+				continue
+			}
+			f := filter{label: strings.TrimSpace(expr[:i]), op: op, value: strings.TrimSpace(expr[i+len(op):])}
+			if f.label == "" {
+				break
+			}
+			if op == "~" {
+				re, err := regexp.Compile(f.value)
+				if err != nil {
+					return filter{}, fmt.Errorf("Invalid filter %q: %v", expr, err)
+				}
+				f.re = re
+			}
+			return f, nil
+		}
+	}
+	return filter{}, fmt.Errorf("Invalid filter %q: expected LABEL OP VALUE", expr)
+}
+
+// match reports whether val matches the filter.
+func (f filter) match(val string) bool {
+	if f.re != nil {
+		return f.re.MatchString(val)
+	}
+	c := compareValues(val, f.value)
+	switch f.op {
+	case "=":
+		return c == 0
+	case "!=":
+		return c != 0
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	}
+	return c >= 0
+}
+
+// compareValues compares two printed values, as numbers if both are numbers and otherwise as strings.
+func compareValues(a, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// column returns the first column labeled label.
+func (cp *cPrinter) column(label string) (column, error) {
+	for _, col := range cp.cols {
+		if col.label == label {
+			return col, nil
+		}
+	}
+	return column{}, fmt.Errorf("Unknown column %s", label)
+}
+
+// arrange filters and sorts the rows as configured by Config.Filters and Config.SortBy. Returns ErrGroupedRows if
+// sorting or filtering rows in tree mode or with exploded columns.
+func (cp *cPrinter) arrange() error {
+	if (len(cp.config.SortBy) > 0 || len(cp.config.Filters) > 0) && (cp.tree != nil || len(cp.explodes) > 0) {
+		return ErrGroupedRows
+	}
+	filters := []filter{}
+	filterCols := columns{}
+	for _, expr := range cp.config.Filters {
+		f, err := parseFilter(expr)
+		if err != nil {
+			return err
+		}
+		col, err := cp.column(f.label)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, f)
+		filterCols = append(filterCols, col)
+	}
+	rows := []int{}
+	for i := 0; i < cp.itemCount; i++ {
+		matches := true
+		for j, f := range filters {
+			matches = matches && f.match(cp.values[filterCols[j]][i])
+		}
+		if matches {
+			rows = append(rows, i)
+		}
+	}
+
+	sortCols := columns{}
+	descending := []bool{}
+	for _, label := range cp.config.SortBy {
+		col, err := cp.column(strings.TrimPrefix(label, "-"))
+		if err != nil {
+			return err
+		}
+		sortCols = append(sortCols, col)
+		descending = append(descending, strings.HasPrefix(label, "-"))
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, col := range sortCols {
+			c := compareValues(cp.values[col][rows[i]], cp.values[col][rows[j]])
+			if descending[k] {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	for _, col := range cp.cols {
+		vals := make([]string, len(rows))
+		raw := make([]interface{}, len(rows))
+		for i, row := range rows {
+			vals[i] = cp.values[col][row]
+			raw[i] = cp.raw[col][row]
+		}
+		cp.values[col] = vals
+		cp.raw[col] = raw
+	}
+	items := make([]interface{}, len(rows))
+	for i, row := range rows {
+		items[i] = cp.items[row]
+	}
+	cp.items = items
+	cp.itemCount = len(rows)
+	return nil
+}
+
+// truncateValues returns the values truncated to width characters by truncate.
+func truncateValues(vals []string, width int) []string {
+	truncated := make([]string, len(vals))
+	for i, val := range vals {
+		truncated[i] = truncate(val, width)
+	}
+	return truncated
+}
+
+// truncate truncates s to width characters, ending with ... if truncated. Zero or negative width means no limit.
+func truncate(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
@@ -0,0 +1,90 @@
+package colprint
+
+import (
+	"bytes"
+)
+
+var arrangeServers = []dummyServer{{Name: "web", Port: 80}, {Name: "db", Port: 5432}, {Name: "cache", Port: 6379}}
+
+func (s *UnitTests) TestFprint_SortBy() {
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, arrangeServers, &Config{SortBy: []string{"Name"}}))
+	s.Equal("Name\ncache\ndb\nweb", buf.String())
+
+	buf.Reset()
+	paths := []PathColumn{{Tag: "Port,2", Path: ".Port"}}
+	s.NoError(Fprint(buf, arrangeServers, &Config{Paths: paths, SortBy: []string{"-Port"}}))
+	s.Equal("Name   Port\ncache  6379\ndb     5432\nweb    80", buf.String())
+
+	s.EqualError(Fprint(buf, arrangeServers, &Config{SortBy: []string{"Unknown"}}), "Unknown column Unknown")
+}
+
+func (s *UnitTests) TestFprint_Filters() {
+	paths := []PathColumn{{Tag: "Port,2", Path: ".Port"}}
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, arrangeServers, &Config{Paths: paths, Filters: []string{"Port>100", "Name!=db"}}))
+	s.Equal("Name   Port\ncache  6379", buf.String())
+
+	buf.Reset()
+	s.NoError(Fprint(buf, arrangeServers, &Config{Filters: []string{"Name~^(web|db)$"}}))
+	s.Equal("Name\nweb\ndb", buf.String())
+
+	s.Error(Fprint(buf, arrangeServers, &Config{Filters: []string{"Name"}}))
+	s.Error(Fprint(buf, arrangeServers, &Config{Filters: []string{"Name~("}}))
+}
+
+func (s *UnitTests) TestFprint_SortByGroupedRows() {
+	buf := new(bytes.Buffer)
+	pods := []dummyPod{{Name: "web", Containers: []*dummyContainer{{Image: "nginx"}, {Image: "envoy"}}}}
+	s.Equal(ErrGroupedRows, Fprint(buf, pods, &Config{SortBy: []string{"Image"}}))
+	s.Equal(ErrGroupedRows, Fprint(buf, pods, &Config{Filters: []string{"Image=envoy"}}))
+	s.Equal(ErrGroupedRows, Fprint(buf, dummyFileTree(), &Config{SortBy: []string{"-Size"}}))
+	s.Equal(ErrGroupedRows, Fprint(buf, dummyFileTree(), &Config{Filters: []string{"Size>0"}}))
+
+	s.NoError(Fprint(buf, dummyFileTree(), &Config{SortBy: []string{}}))
+}
+
+func (s *UnitTests) TestFprint_MaxColumnWidth() {
+	width := 4
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, arrangeServers, &Config{MaxColumnWidth: &width}))
+	s.Equal("Name\nweb\ndb\nc...", buf.String())
+
+	buf.Reset()
+	org := "org"
+	s.NoError(Fprint(buf, arrangeServers[2:], &Config{MaxColumnWidth: &width, Format: &org}))
+	s.Equal("| Name |\n|------|\n| c... |", buf.String())
+
+	buf.Reset()
+	s.NoError(FprintCSV(buf, arrangeServers[2:], &Config{MaxColumnWidth: &width}))
+	s.Equal("Name\ncache\n", buf.String())
+
+	buf.Reset()
+	s.NoError(FprintJSON(buf, arrangeServers[2:], &Config{MaxColumnWidth: &width}))
+	s.Contains(buf.String(), `"cache"`)
+
+	buf.Reset()
+	s.NoError(FprintDiff(buf, arrangeServers[1:2], arrangeServers[1:], "Name", &Config{MaxColumnWidth: &width}))
+	s.Contains(buf.String(), "cache")
+}
+
+func (s *UnitTests) TestParseFilter() {
+	f, err := parseFilter("Last name <= Nordmann")
+	s.NoError(err)
+	s.Equal(filter{label: "Last name", op: "<=", value: "Nordmann"}, f)
+
+	_, err = parseFilter("=x")
+	s.Error(err)
+}
+
+func (s *UnitTests) TestCompareValues() {
+	s.Equal(-1, compareValues("9", "10"))
+	s.Equal(1, compareValues("b", "a"))
+	s.Equal(0, compareValues("1.0", "1"))
+}
+
+func (s *UnitTests) TestTruncate() {
+	s.Equal("abc", truncate("abc", 0))
+	s.Equal("ab", truncate("abc", 2))
+	s.Equal("æø...", truncate("æøåæøå", 5))
+}
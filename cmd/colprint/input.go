@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/peteabre/colprint"
+)
+
+// Input formats.
+const (
+	inputAuto       = "auto"
+	inputJSON       = "json"
+	inputCSV        = "csv"
+	inputTSV        = "tsv"
+	inputWhitespace = "ws"
+)
+
+// record is a row of input, holding the headers and cells in input order.
+type record struct {
+	headers []string
+	cells   []interface{}
+}
+
+func (r record) Headers() []string {
+	return r.headers
+}
+
+func (r record) Cells() []interface{} {
+	return r.cells
+}
+
+// readRecords parses input in the given format into records. The auto format detects JSON by a leading [ or {,
+// and otherwise detects the delimiter from the first line.
+func readRecords(data []byte, format string) ([]colprint.Row, error) {
+	if format == inputAuto {
+		format = detectFormat(data)
+	}
+	switch format {
+	case inputJSON:
+		return readJSON(data)
+	case inputCSV:
+		return readCSV(data, ',')
+	case inputTSV:
+		return readCSV(data, '\t')
+	case inputWhitespace:
+		return readWhitespace(data)
+	}
+	return nil, fmt.Errorf("unknown input format %q", format)
+}
+
+// detectFormat returns the format of input.
+func detectFormat(data []byte) string {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return inputJSON
+	}
+	firstLine := string(trimmed)
+	if i := strings.IndexByte(firstLine, '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+	switch {
+	case strings.Contains(firstLine, "\t"):
+		return inputTSV
+	case strings.Contains(firstLine, ","):
+		return inputCSV
+	}
+	return inputWhitespace
+}
+
+// readJSON parses a JSON array of objects, or a stream of objects such as NDJSON, into records keeping the order
+// of the keys of each object.
+func readJSON(data []byte) ([]colprint.Row, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	array := bytes.HasPrefix(bytes.TrimSpace(data), []byte("["))
+	if array {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+	rows := []colprint.Row{}
+	for dec.More() {
+		r, err := readObject(dec)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, r)
+	}
+	if array {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
+
+// readObject reads a JSON object from dec into a record.
+func readObject(dec *json.Decoder) (record, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return record{}, err
+	}
+	if tok != json.Delim('{') {
+		return record{}, fmt.Errorf("expected a JSON object, got %v", tok)
+	}
+	r := record{}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return record{}, err
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return record{}, err
+		}
+		val, err := jsonValue(raw)
+		if err != nil {
+			return record{}, err
+		}
+		r.headers = append(r.headers, key.(string))
+		r.cells = append(r.cells, val)
+	}
+	_, err = dec.Token()
+	return r, err
+}
+
+// jsonObject is a nested JSON object, printed as JSON text.
+type jsonObject map[string]interface{}
+
+func (o jsonObject) String() string {
+	b, err := json.Marshal(map[string]interface{}(o))
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
+
+// jsonValue decodes a JSON value, keeping numbers as written.
+func jsonValue(raw json.RawMessage) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return nestedValue(v), nil
+}
+
+// nestedValue returns v with the nested objects of arrays and objects as jsonObjects.
+func nestedValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			v[key] = nestedValue(val)
+		}
+		return jsonObject(v)
+	case []interface{}:
+		for i, item := range v {
+			v[i] = nestedValue(item)
+		}
+	}
+	return v
+}
+
+// readCSV parses delimited text with a header record into records.
+func readCSV(data []byte, delim rune) ([]colprint.Row, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = delim
+	r.FieldsPerRecord = -1
+	if delim == '\t' {
+		r.LazyQuotes = true
+	}
+	records, err := r.ReadAll()
+	if err != nil || len(records) == 0 {
+		return nil, err
+	}
+	return newRecords(records[0], records[1:]), nil
+}
+
+// readWhitespace parses text with fields separated by whitespace into records, with the headers on the first line.
+// Extra fields are joined into the last column, as for the command column of ps.
+func readWhitespace(data []byte) ([]colprint.Row, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	var headers []string
+	lines := [][]string{}
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if headers == nil {
+			headers = fields
+			continue
+		}
+		if len(fields) > len(headers) {
+			fields = append(fields[:len(headers)-1], strings.Join(fields[len(headers)-1:], " "))
+		}
+		lines = append(lines, fields)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return newRecords(headers, lines), nil
+}
+
+// newRecords creates records of cells with the given headers.
+func newRecords(headers []string, lines [][]string) []colprint.Row {
+	rows := make([]colprint.Row, len(lines))
+	for i, line := range lines {
+		cells := make([]interface{}, len(line))
+		for j, cell := range line {
+			cells[j] = cell
+		}
+		rows[i] = record{headers: headers, cells: cells}
+	}
+	return rows
+}
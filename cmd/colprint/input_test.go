@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+)
+
+func (s *UnitTests) TestDetectFormat() {
+	s.Equal(inputJSON, detectFormat([]byte("  [{}]")))
+	s.Equal(inputJSON, detectFormat([]byte("{}\n{}")))
+	s.Equal(inputTSV, detectFormat([]byte("a\tb\n1,2\t3")))
+	s.Equal(inputCSV, detectFormat([]byte("a,b\n1,2")))
+	s.Equal(inputWhitespace, detectFormat([]byte("PID CMD\n1 init")))
+}
+
+func (s *UnitTests) TestReadRecords_NDJSON() {
+	rows, err := readRecords([]byte("{\"b\": 1, \"a\": [1, 2]}\n{\"c\": null}\n"), inputAuto)
+	s.NoError(err)
+	s.Len(rows, 2)
+	s.Equal([]string{"b", "a"}, rows[0].Headers())
+	s.Equal([]interface{}{json.Number("1"), []interface{}{json.Number("1"), json.Number("2")}}, rows[0].Cells())
+	s.Equal([]interface{}{nil}, rows[1].Cells())
+
+	_, err = readRecords([]byte("[1, 2]"), inputAuto)
+	s.Error(err)
+}
+
+func (s *UnitTests) TestReadRecords_CSV() {
+	rows, err := readRecords([]byte("name,city\n\"Nordmann, Ola\",Oslo\n"), inputAuto)
+	s.NoError(err)
+	s.Equal([]interface{}{"Nordmann, Ola", "Oslo"}, rows[0].Cells())
+
+	rows, err = readRecords([]byte("name\tcity\nola\tOslo\n"), inputTSV)
+	s.NoError(err)
+	s.Equal([]string{"name", "city"}, rows[0].Headers())
+	s.Equal([]interface{}{"ola", "Oslo"}, rows[0].Cells())
+}
+
+func (s *UnitTests) TestReadRecords_Whitespace() {
+	rows, err := readRecords([]byte("PID  CMD\n\n1    /sbin/init splash\n"), inputAuto)
+	s.NoError(err)
+	s.Len(rows, 1)
+	s.Equal([]interface{}{"1", "/sbin/init splash"}, rows[0].Cells())
+}
@@ -0,0 +1,105 @@
+// Command colprint prints JSON, NDJSON, CSV, TSV or whitespace-delimited input from stdin as columns.
+//
+// Usage:
+//
+//	curl -s https://example.com/users | colprint -columns name,age -sort-by -age -filter 'age>=18'
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/peteabre/colprint"
+)
+
+// stringList is a flag that can be given more than once.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ", ")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// errUsage is wrapped by the errors of invalid command line arguments, which are reported by the flag package.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout)
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp), errors.Is(err, colprint.ErrBrokenPipe):
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "colprint:", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the command line arguments, reads the input from in and prints it to out.
+func run(args []string, in io.Reader, out io.Writer) error {
+	flags := flag.NewFlagSet("colprint", flag.ContinueOnError)
+	input := flags.String("input", "auto", "input format: auto, json, csv, tsv or ws")
+	format := flags.String("format", "text", "output format: "+strings.Join(colprint.RendererNames(), ", "))
+	table := flags.String("table", "", "table of the statements of the sql and copy formats, e.g. public.users")
+	columns := flags.String("columns", "", "comma separated labels of the columns to print, in order")
+	paths := flags.String("paths", "", "path columns to add, as NAME:PATH,NAME:PATH, e.g. IP:.addresses[0].ip")
+	sortBy := flags.String("sort-by", "", "comma separated labels to sort by, prefixed by - for descending order")
+	width := flags.Int("width", 0, "maximum width of the columns, 0 for no limit")
+	pageSize := flags.Int("page-size", 0, "number of rows per page, each starting with the header, 0 for no paging")
+	var filters stringList
+	flags.Var(&filters, "filter", "print only rows matching LABEL OP VALUE, where OP is =, !=, <, <=, >, >= or ~; "+
+		"can be repeated")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+
+	if _, ok := colprint.LookupRenderer(*format); !ok {
+		return fmt.Errorf("unknown output format %q", *format)
+	}
+	conf := &colprint.Config{Format: format, Filters: filters, MaxColumnWidth: width, PageSize: pageSize,
+		SQLTable: table, Warn: func(err error) {
+			fmt.Fprintln(os.Stderr, "colprint: warning:", err)
+		}}
+	if *columns != "" {
+		conf.Columns = strings.Split(*columns, ",")
+	}
+	if *sortBy != "" {
+		conf.SortBy = strings.Split(*sortBy, ",")
+	}
+	if *paths != "" {
+		cols, err := colprint.ParsePathColumns(*paths)
+		if err != nil {
+			return err
+		}
+		conf.Paths = cols
+	}
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return err
+	}
+	rows, err := readRecords(data, *input)
+	if err != nil {
+		return err
+	}
+	if err := colprint.Fprint(out, rows, conf); err != nil {
+		return err
+	}
+	if *format == "xlsx" {
+		// nothing may follow the zip archive of the workbook
+		return nil
+	}
+	_, err = io.WriteString(out, "\n")
+	return err
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type UnitTests struct {
+	suite.Suite
+}
+
+func TestRunUnitTests(t *testing.T) {
+	suite.Run(t, new(UnitTests))
+}
+
+const users = `[
+	{"name": "ola", "age": 35, "address": {"city": "Oslo"}},
+	{"name": "kari", "age": 7},
+	{"name": "per", "age": 41}
+]`
+
+func (s *UnitTests) TestRun() {
+	out := new(bytes.Buffer)
+	s.NoError(run(nil, strings.NewReader(users), out))
+	s.Equal("name  age  address\nola   35   {\"city\":\"Oslo\"}\nkari  7    \nper   41   \n", out.String())
+}
+
+func (s *UnitTests) TestRun_Flags() {
+	out := new(bytes.Buffer)
+	args := []string{"-columns", "name,age", "-sort-by", "-age", "-filter", "age>=18", "-width", "2"}
+	s.NoError(run(args, strings.NewReader(users), out))
+	s.Equal("name  age\npe    41\nol    35\n", out.String())
+
+	out.Reset()
+	s.NoError(run([]string{"-paths", "CITY:.address.city", "-columns", "name,CITY"}, strings.NewReader(users), out))
+	s.Equal("name  CITY\nola   Oslo\nkari  \nper   \n", out.String())
+}
+
+func (s *UnitTests) TestRun_HTML() {
+	out := new(bytes.Buffer)
+	s.NoError(run([]string{"-format", "html", "-columns", "name"}, strings.NewReader(users), out))
+	s.Contains(out.String(), "<tr><td>kari</td></tr>")
+
+	out.Reset()
+	s.NoError(run([]string{"-format", "json", "-columns", "name,age", "-filter", "age<10"}, strings.NewReader(users), out))
+	s.Equal("[\n  {\"name\": \"kari\", \"age\": 7}\n]\n", out.String())
+
+	out.Reset()
+	s.NoError(run([]string{"-format", "yaml", "-columns", "name,age", "-filter", "age<10"}, strings.NewReader(users), out))
+	s.Equal("- name: kari\n  age: 7\n", out.String())
+
+	out.Reset()
+	s.NoError(run([]string{"-format", "org", "-columns", "name,age", "-filter", "age<10"}, strings.NewReader(users), out))
+	s.Equal("| name | age |\n|------+-----|\n| kari |   7 |\n", out.String())
+}
+
+func (s *UnitTests) TestRun_SQL() {
+	out := new(bytes.Buffer)
+	args := []string{"-format", "sql", "-table", "users", "-columns", "name,age", "-filter", "age<10"}
+	s.NoError(run(args, strings.NewReader(users), out))
+	s.Equal("INSERT INTO \"users\" (\"name\", \"age\") VALUES ('kari', 7);\n", out.String())
+
+	out.Reset()
+	args = []string{"-format", "copy", "-table", "users", "-columns", "name,age", "-filter", "age<10"}
+	s.NoError(run(args, strings.NewReader(users), out))
+	s.Equal("COPY \"users\" (\"name\", \"age\") FROM stdin;\nkari\t7\n\\.\n", out.String())
+
+	s.Error(run([]string{"-format", "sql"}, strings.NewReader(users), out))
+}
+
+func (s *UnitTests) TestRun_XLSX() {
+	out := new(bytes.Buffer)
+	s.NoError(run([]string{"-format", "xlsx"}, strings.NewReader(users), out))
+	_, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	s.NoError(err)
+	s.False(strings.HasSuffix(out.String(), "\n"))
+}
+
+func (s *UnitTests) TestRun_Errors() {
+	out := new(bytes.Buffer)
+	s.True(errors.Is(run([]string{"-unknown"}, strings.NewReader(users), out), errUsage))
+	s.EqualError(run([]string{"-format", "xml"}, strings.NewReader(users), out), `unknown output format "xml"`)
+	s.EqualError(run([]string{"-input", "xml"}, strings.NewReader(users), out), `unknown input format "xml"`)
+	s.Error(run([]string{"-sort-by", "unknown"}, strings.NewReader(users), out))
+}
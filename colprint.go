@@ -9,8 +9,11 @@ import (
 	"strings"
 	"math"
 	"sort"
-	"github.com/ryanuber/columnize"
 	"bytes"
+	"errors"
+	"database/sql/driver"
+	"unicode/utf8"
+	"text/template"
 )
 
 const TagName = "colprint"
@@ -21,12 +24,110 @@ const (
 	TagValueTraverse = "=>"
 )
 
+// Options of the traverse tag value, e.g. `colprint:"=>,explode"`.
+const (
+	// TagOptionExplode traverses a slice of structs, printing a row for each element.
+	TagOptionExplode = "explode"
+	// TagOptionTree prints the struct as a tree, with the field holding a slice of the children of the struct.
+	TagOptionTree = "tree"
+	// TagOptionPrefix prefixes the labels of the traversed columns, e.g. `colprint:"=>,prefix=Owner "`.
+	TagOptionPrefix = "prefix="
+)
+
+// Options of column tags, following the label and order, e.g. `colprint:"Name,1,class=name"`.
+const (
+	// TagOptionClass sets the class attribute of the cells of the column in HTML output.
+	TagOptionClass = "class="
+	// TagOptionSQL sets the name of the column in SQL output, instead of the label, e.g.
+	// `colprint:"First name,1,sql=first_name"`.
+	TagOptionSQL = "sql="
+)
+
 // Config holds configuration used when printing columns
 type Config struct {
 	// MaxPrintedSliceItems represents the maximum number og slice items to list.
 	MaxPrintedSliceItems *int
 	// FloatPrecision represents the precision used when printing floats.
 	FloatPrecision *int
+	// BlankRepeatedValues prints blanks instead of repeating the values of the parent item on the rows exploded
+	// from it.
+	BlankRepeatedValues *bool
+	// PathLabels prefixes the labels of traversed columns with the dotted path of the traversed fields, e.g.
+	// "Owner.Name". Traverse tags with a prefix option use that prefix instead.
+	PathLabels *bool
+	// DuplicateLabels represents how columns with the same label are handled.
+	DuplicateLabels *DuplicateLabels
+	// Warn is called with warnings, such as duplicate labels. If nil, warnings are discarded.
+	Warn func(error)
+	// StrictEmbedding disables finding columns in untagged anonymous embedded structs. Embedded structs then
+	// need the traverse tag to contribute columns.
+	StrictEmbedding *bool
+	// TreeMaxDepth limits how many levels of children are printed below each item in tree mode. Negative means
+	// unlimited.
+	TreeMaxDepth *int
+	// TreeCollapsedMarker is appended to the first column of nodes whose children are hidden by TreeMaxDepth.
+	TreeCollapsedMarker *string
+	// Computed are columns computed from the printed structs, ordered like tagged fields.
+	Computed []ComputedColumn
+	// Templates are columns computed by text/templates executed on the printed structs, ordered like tagged
+	// fields.
+	Templates []TemplateColumn
+	// TemplateFuncs are added to the functions available to Templates.
+	TemplateFuncs template.FuncMap
+	// Paths are columns holding the values at path expressions evaluated on the printed structs, maps or rows,
+	// e.g. ".status.addresses[0].ip". Columns of structs are ordered like tagged fields, while columns of maps
+	// and rows are printed after the map keys or row headers.
+	Paths []PathColumn
+	// PageSize splits the printed items into pages of PageSize items, each starting with the header line.
+	// Zero means no paging.
+	PageSize *int
+	// MixedTypes represents how slices holding items of different struct types are printed.
+	MixedTypes *MixedTypes
+	// HighlightChanges highlights the cells that changed since the previous snapshot of a LiveTable drawn on a
+	// terminal.
+	HighlightChanges *bool
+	// Color prints diffs with ANSI colors: added rows green, removed rows red, and changed cells yellow.
+	Color *bool
+	// SortBy sorts the rows by the values of the columns with the given labels, in descending order for labels
+	// prefixed by -. Values are compared as numbers if both are numbers, and otherwise as strings. Not supported
+	// in tree mode or with exploded columns.
+	SortBy []string
+	// Filters restricts the rows to those matching all the filter expressions, of the form LABEL OP VALUE where
+	// OP is one of =, !=, <, <=, >, >= or ~ for matching a regular expression, e.g. "Age>=30". Not supported in
+	// tree mode or with exploded columns.
+	Filters []string
+	// MaxColumnWidth truncates values longer than MaxColumnWidth characters in the text, latex, asciidoc, rst and
+	// org formats and in LiveTables. Other formats and diffs print the values untruncated. Zero means no limit.
+	MaxColumnWidth *int
+	// HTMLRowClass returns the class attribute of the row of an item in HTML output. The item is the struct, map
+	// or Row the row was added from, or nil for blank rows.
+	HTMLRowClass func(item interface{}) string
+	// HTMLSortAttributes adds the raw values of the cells as data-sort attributes in HTML output.
+	HTMLSortAttributes *bool
+	// HTMLDocument prints a standalone HTML document instead of only the table.
+	HTMLDocument *bool
+	// HTMLTitle is the title of standalone HTML documents.
+	HTMLTitle *string
+	// Format selects the Renderer of Fprint by the name it is registered under. The built-in formats are text,
+	// html, csv, json, yaml, xlsx, sql, copy, latex, asciidoc, rst and org, printed as by FprintHTML, FprintCSV
+	// and so on. Defaults to "text", printing aligned columns of plain text.
+	Format *string
+	// LaTeXBooktabs draws the rules of LaTeX tables with the booktabs package, i.e. \toprule, \midrule and
+	// \bottomrule instead of \hline.
+	LaTeXBooktabs *bool
+	// RSTSimple prints reStructuredText simple tables instead of grid tables.
+	RSTSimple *bool
+	// SQLDialect represents how identifiers and values are quoted in SQL output.
+	SQLDialect *SQLDialect
+	// SQLBatchSize is the number of rows inserted by each INSERT statement in SQL output. Zero or negative means
+	// all rows in a single statement.
+	SQLBatchSize *int
+	// SQLTable is the table of the statements of the sql and copy formats, which may be qualified by a schema,
+	// e.g. "public.users".
+	SQLTable *string
+	// Columns restricts and orders the printed columns by label. Labels not matching a tagged struct field are
+	// reported as errors, while for maps and rows the listed columns are printed even if no item has them.
+	Columns []string
 }
 // Sprint is a convenience method for creating a string from a struct or slice of structs using default config
 func Sprint(s interface{}) (string, error) {
@@ -43,13 +144,52 @@ func Print(s interface{}) error {
 // Fprint prints struct or slice to provided io.Writer using provided config.
 // If config is nil, default config will be used.
 func Fprint(w io.Writer, s interface{}, c ... *Config) error {
+	printers, err := load(s, c...)
+	if err != nil {
+		return err
+	}
+	r, err := lookupRenderer(*printers[0].config.Format)
+	if err != nil {
+		return err
+	}
+	// Print to provided Writer
+	return fprintAll(w, printers, r)
+}
+
+// load adds a struct or slice to a cPrinter using provided config, and filters and sorts its rows. Returns a
+// cPrinter per table to print, which is more than one only for items of different struct types printed in separate
+// tables.
+func load(s interface{}, c ... *Config) ([]*cPrinter, error) {
+	printers, err := loadValue(s, c...)
+	if err != nil {
+		return nil, err
+	}
+	for _, cp := range printers {
+		if err := cp.arrange(); err != nil {
+			return nil, err
+		}
+	}
+	return printers, nil
+}
+
+// loadValue adds a struct or slice to a cPrinter using provided config, returning a cPrinter per table to print.
+func loadValue(s interface{}, c ... *Config) ([]*cPrinter, error) {
 	var conf *Config
 	if len(c) > 0 {
 		conf = c[0]
 	}
-	cp := cPrinter{config: mergeConfig(createDefaultConfig(), conf)}
+	cp := &cPrinter{config: mergeConfig(createDefaultConfig(), conf)}
+	if t, ok := s.(Table); ok {
+		if err := cp.addRows(rowsOfTable(t), cp.config.Paths); err != nil {
+			return nil, err
+		}
+		return []*cPrinter{cp}, nil
+	}
 	val := reflect.ValueOf(s)
 	kind := val.Kind()
+	if kind == reflect.Invalid || (kind == reflect.Ptr && val.IsNil()) {
+		return nil, ErrNilValue
+	}
 
 	// If its a pointer, do an indirect...
 	if kind == reflect.Ptr {
@@ -59,20 +199,62 @@ func Fprint(w io.Writer, s interface{}, c ... *Config) error {
 
 	// Check if s is a slice/array or not
 	if kind == reflect.Slice || kind == reflect.Array {
+		if rows, ok := rowsOf(val); ok {
+			// add maps and rows with the union of their headers as columns
+			if err := cp.addRows(rows, cp.config.Paths); err != nil {
+				return nil, err
+			}
+			return []*cPrinter{cp}, nil
+		}
+		if hasMixedTypes(val) {
+			return cp.loadMixed(val)
+		}
+		cp.sample = firstItem(val)
+		if err := cp.initColumns(sliceItemType(val)); err != nil {
+			return nil, err
+		}
 		// add each item in slice to cPrinter
 		for i := 0; i < val.Len(); i ++ {
 			if err := cp.add(val.Index(i).Interface()); err != nil {
-				return err
+				return nil, err
 			}
 		}
+	} else if row, ok := rowOf(val); ok {
+		// add the map or row to cPrinter
+		if err := cp.addRows([]Row{row}, cp.config.Paths); err != nil {
+			return nil, err
+		}
 	} else {
 		// add the item to cPrinter
 		if err := cp.add(val.Interface()); err != nil {
+			return nil, err
+		}
+	}
+	return []*cPrinter{cp}, nil
+}
+
+// fprintAll prints the tables of printers to the provided io.Writer using renderer r, all at once if r is a
+// TablesRenderer, and otherwise separated by blank lines, or on separate pages when writing to a pageWriter.
+func fprintAll(w io.Writer, printers []*cPrinter, r Renderer) error {
+	if tr, ok := r.(TablesRenderer); ok {
+		tables := make([]*TableModel, len(printers))
+		for i, cp := range printers {
+			tables[i] = cp.model()
+		}
+		return tr.RenderTables(w, tables)
+	}
+	for i, cp := range printers {
+		if pw, ok := w.(*pageWriter); ok && i > 0 {
+			pw.newPage()
+		} else if i > 0 {
+			if err := writeString(w, "\n\n"); err != nil {
+				return err
+			}
+		}
+		if err := r.Render(w, cp.model()); err != nil {
 			return err
 		}
 	}
-	// Print to provided Writer
-	cp.fprint(w)
 	return nil
 }
 
@@ -81,6 +263,12 @@ type column struct {
 	fieldIndex *[]int
 	label      string
 	order      int
+	// compute computes the value of the column from the struct at fieldIndex, instead of using a field
+	compute *computer
+	// class is the class attribute of the cells of the column in HTML output
+	class string
+	// sqlName is the name of the column in SQL output, if not the label
+	sqlName string
 }
 
 // columns is a sortable list of column structs
@@ -90,7 +278,11 @@ func (s columns) Len() int {
 	return len(s)
 }
 
+// Less orders columns exploded from slices after the columns of their parent, and otherwise by order.
 func (s columns) Less(i, j int) bool {
+	if di, dj := s[i].explodeDepth(), s[j].explodeDepth(); di != dj {
+		return di < dj
+	}
 	return s[i].order < s[j].order
 }
 
@@ -104,94 +296,231 @@ type cPrinter struct {
 	cols columns
 	// Map containing values for all columns
 	values map[column][]string
+	// Map containing the raw values for all columns, before formatting
+	raw map[column][]interface{}
+	// The items the rows were added from
+	items []interface{}
 	// Keeps track of number of items appended to the ColPrinter
 	itemCount int
 	// Configuration for the printer
 	config *Config
+	// Struct types currently being traversed while finding columns
+	traversing map[reflect.Type]bool
+	// The first item, whose values limit how deep recursive struct types are traversed while finding columns
+	sample reflect.Value
+	// Paths to the slices exploded by the columns, outermost first
+	explodes [][]int
+	// Field index of the children of the items in tree mode
+	tree *[]int
 }
 
-// add adds a struct columns and values
+// add adds a struct columns and values. A nil item is added as a row of blanks, and an item with exploded slices
+// is added as a row for each combination of slice elements.
 func (cp *cPrinter) add(s interface{}) error {
+	v := itemValue(reflect.ValueOf(s))
 	// Init columns if it's not already done
 	if cp.cols == nil {
-		cp.init()
-		err := cp.findColumns(s)
-		if err != nil {
+		if !v.IsValid() {
+			return ErrNilValue
+		}
+		cp.sample = v
+		if err := cp.initColumns(v.Type()); err != nil {
 			return err
 		}
-		for _, col := range cp.cols {
-			cp.initColumn(col)
+	}
+	if !v.IsValid() {
+		cp.appendRow(nil, make([]interface{}, len(cp.cols)), make([]string, len(cp.cols)))
+		return nil
+	}
+	if err := checkStruct(v.Type()); err != nil {
+		return err
+	}
+	if cp.tree != nil {
+		return cp.addTree(v, "", "", 0, make(map[uintptr]bool))
+	}
+	return cp.addItem(v, "", "", "")
+}
+
+// addItem adds the values of struct v as one or more rows. The first column is prefixed by prefix on the first row
+// and by cont on the following rows, and marker is appended to it on the first row.
+func (cp *cPrinter) addItem(v reflect.Value, prefix, cont, marker string) error {
+	// Find values before adding them, so that a failing field leaves no partial row
+	choices := cp.explodeChoices(v)
+	rows := make([][]string, len(choices))
+	raws := make([][]interface{}, len(choices))
+	for r, choice := range choices {
+		rows[r] = make([]string, len(cp.cols))
+		raws[r] = make([]interface{}, len(cp.cols))
+		for i, col := range cp.cols {
+			if r > 0 && *cp.config.BlankRepeatedValues && col.sameChoices(choice, choices[r-1]) {
+				continue
+			}
+			field, err := fieldValue(v, col, choice)
+			if err != nil {
+				return err
+			}
+			rows[r][i] = cp.valueOf(field)
+			raws[r][i] = field
+		}
+		if len(cp.cols) > 0 && r == 0 {
+			rows[r][0] = prefix + rows[r][0] + marker
+		} else if len(cp.cols) > 0 {
+			rows[r][0] = cont + rows[r][0]
 		}
 	}
 	// Add values
-	for _, col := range cp.cols {
-		v := reflect.ValueOf(s)
-		if v.Kind() == reflect.Ptr {
-			v = reflect.Indirect(v)
-		}
-		val := cp.valueOf(v.FieldByIndex(*col.fieldIndex).Interface())
-		cp.values[col] = append(cp.values[col], val)
+	var item interface{}
+	if v.CanInterface() {
+		item = v.Interface()
+	}
+	for r, vals := range rows {
+		cp.appendRow(item, raws[r], vals)
 	}
-	cp.itemCount++
 	return nil
 }
 
-//fprint prints the columns to the provided io.Writer.
-func (cp *cPrinter) fprint(w io.Writer) {
-	// Add header line
-	str := []string{}
-	headers := ""
+// appendRow appends a row of raw and formatted values, added from item.
+func (cp *cPrinter) appendRow(item interface{}, raw []interface{}, vals []string) {
 	for i, col := range cp.cols {
-		headers += col.label
-		if i != len(cp.cols)-1 {
-			headers += "|"
+		cp.raw[col] = append(cp.raw[col], raw[i])
+		cp.values[col] = append(cp.values[col], vals[i])
+	}
+	cp.items = append(cp.items, item)
+	cp.itemCount++
+}
+
+// initColumns finds the columns of struct type t and initializes them. A nil type initializes no columns.
+func (cp *cPrinter) initColumns(t reflect.Type) error {
+	cp.init()
+	if t == nil {
+		return nil
+	}
+	if err := checkStruct(t); err != nil {
+		return err
+	}
+	if err := cp.findColumns(t); err != nil {
+		return err
+	}
+	if err := cp.selectColumns(); err != nil {
+		return err
+	}
+	for _, col := range cp.cols {
+		cp.initColumn(col)
+	}
+	cp.explodes = cp.cols.explodes()
+	return nil
+}
+
+// sliceItemType returns the struct type of the items in a slice or array. For slices of interfaces, the type of
+// the first non-nil item is used. Returns nil if the type cannot be determined.
+func sliceItemType(v reflect.Value) reflect.Type {
+	t := v.Type().Elem()
+	if t.Kind() == reflect.Interface {
+		for i := 0; i < v.Len(); i++ {
+			if item := itemValue(v.Index(i)); item.IsValid() {
+				return item.Type()
+			}
 		}
+		return nil
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// fieldValue returns the value of the field of column col in struct v, taking elements of exploded slices from
+// choices. Returns nil if the field is behind a nil pointer or a missing slice element, and a FieldAccessError if
+// the field is unexported.
+func fieldValue(v reflect.Value, col column, choices map[string]int) (interface{}, error) {
+	field := walk(v, *col.fieldIndex, choices)
+	if col.compute != nil {
+		field = itemValue(field)
+	}
+	if !field.IsValid() {
+		return nil, nil
 	}
-	str = append(str, headers)
+	if !field.CanInterface() {
+		return nil, &FieldAccessError{Field: col.path(v.Type()), Reason: "field is unexported"}
+	}
+	if col.compute != nil {
+		return col.compute.fn(field), nil
+	}
+	return field.Interface(), nil
+}
 
-	// Add a line for each item appended
-	for i := 0; i < cp.itemCount; i++ {
-		vals := ""
-		for j, col := range cp.cols {
-			vals += cp.values[col][i]
-			if j != len(cp.cols)-1 {
-				vals += "|"
+// widths returns the width of each column, which is the width of its widest value or label. Values are measured
+// truncated to Config.MaxColumnWidth.
+func (cp *cPrinter) widths() []int {
+	widths := make([]int, len(cp.cols))
+	for i, col := range cp.cols {
+		widths[i] = utf8.RuneCountInString(col.label)
+		for _, val := range cp.values[col] {
+			if l := utf8.RuneCountInString(truncate(val, *cp.config.MaxColumnWidth)); l > widths[i] {
+				widths[i] = l
 			}
 		}
-		str = append(str, vals)
 	}
-	// Print to given Writer
-	fmt.Fprint(w, columnize.SimpleFormat(str))
+	return widths
 }
 
-// init initializes the array containing columns, and the map containing the values for each column.
+// init initializes the array containing columns, and the maps containing the values for each column.
 func (cp *cPrinter) init() {
 	cp.cols = columns{}
 	cp.values = make(map[column][]string)
+	cp.raw = make(map[column][]interface{})
 }
 
 // initColumn initializes the array containing column values.
 func (cp *cPrinter) initColumn(col column) {
 	cp.values[col] = make([]string, 0)
+	cp.raw[col] = make([]interface{}, 0)
+}
+
+// findColumns extracts which columns of struct type t should be printed and adds them to columns, sorted by
+// order. Returns a TagError if any field contains a incomplete tag, and a DuplicateLabelError if columns have
+// the same label and Config.DuplicateLabels is DuplicateLabelsError.
+func (cp *cPrinter) findColumns(t reflect.Type) error {
+	if err := cp.collectColumns(t); err != nil {
+		return err
+	}
+	if err := cp.checkTree(t); err != nil {
+		return err
+	}
+	if err := cp.appendComputedColumns(); err != nil {
+		return err
+	}
+	if err := cp.appendTemplateColumns(); err != nil {
+		return err
+	}
+	if err := cp.appendPathColumns(); err != nil {
+		return err
+	}
+	sort.Stable(cp.cols)
+	return cp.checkLabels(t)
 }
 
-// findColumns extracts which columns should be printed and adds them to columns. Returns an error if any field
-// contains a incomplete tag.
-func (cp *cPrinter) findColumns(s interface{}, fieldIndex ... int) error {
-	v := reflect.ValueOf(s)
-	if v.Kind() == reflect.Ptr {
-		v = reflect.Indirect(v)
+// collectColumns adds the columns of struct type t, with field indexes prefixed by fieldIndex, to columns.
+func (cp *cPrinter) collectColumns(t reflect.Type, fieldIndex ... int) error {
+	if cp.traversing == nil {
+		cp.traversing = make(map[reflect.Type]bool)
 	}
+	cp.traversing[t] = true
+	defer delete(cp.traversing, t)
 
-	for i := 0; i < v.NumField(); i++ {
-		fIndex := append(fieldIndex, i)
-		field := v.Type().Field(i)
+	for i := 0; i < t.NumField(); i++ {
+		fIndex := append(append([]int{}, fieldIndex...), i)
+		field := t.Field(i)
 		tag := field.Tag.Get(TagName)
-		switch tag {
-		case TagValueEmpty, TagValueSkip:
+		switch {
+		case tag == TagValueEmpty && field.Anonymous && !*cp.config.StrictEmbedding:
+			if err := cp.promoteColumns(t, field, fIndex...); err != nil {
+				return err
+			}
+		case tag == TagValueEmpty, tag == TagValueSkip:
 			// Do nothing
-		case TagValueTraverse:
-			if err := cp.traverseStruct(v.FieldByIndex([]int{i}), fIndex...); err != nil {
+		case isTraverseTag(tag):
+			if err := cp.traverseStruct(field, fIndex...); err != nil {
 				return err
 			}
 		default:
@@ -200,52 +529,220 @@ func (cp *cPrinter) findColumns(s interface{}, fieldIndex ... int) error {
 			}
 		}
 	}
-	sort.Sort(cp.cols)
+	cp.appendMethodColumns(t, fieldIndex)
 	return nil
 }
 
 // appendColumn appends a tagged field to the list of columns.
 func (cp *cPrinter) appendColumn(tag string, field reflect.StructField, fieldIndex *[]int) error {
+	col, err := parseColumnTag(tag)
+	if err != nil {
+		return &TagError{Field: field.Name, Tag: tag, Reason: err.Error()}
+	}
+	col.fieldIndex = fieldIndex
+	cp.cols = append(cp.cols, col)
+	return nil
+}
+
+// parseColumnTag parses a column tag of the form LABEL[,ORDER[,OPTION...]] into a column without field index.
+// Columns without order are ordered last.
+func parseColumnTag(tag string) (column, error) {
 	args := strings.Split(tag, ",")
-	switch len(args) {
-	case 1:
-		cp.cols = append(cp.cols, column{fieldIndex, args[0], math.MaxInt32})
-	case 2:
+	col := column{label: args[0], order: math.MaxInt32}
+	if len(args) > 1 && args[1] != "" {
 		order, err := strconv.Atoi(args[1])
 		if err != nil {
-			return fmt.Errorf("Invalid order on field %s", field.Name)
+			return column{}, errors.New("invalid order")
 		}
-		cp.cols = append(cp.cols, column{fieldIndex, args[0], order})
-	default:
-		return fmt.Errorf("Invalid number of tag arguments on field %s", field.Name)
+		col.order = order
 	}
-	return nil
+	for i := 2; i < len(args); i++ {
+		switch {
+		case strings.HasPrefix(args[i], TagOptionClass):
+			col.class = strings.TrimPrefix(args[i], TagOptionClass)
+		case strings.HasPrefix(args[i], TagOptionSQL):
+			col.sqlName = strings.TrimPrefix(args[i], TagOptionSQL)
+		default:
+			return column{}, fmt.Errorf("unknown option %s", args[i])
+		}
+	}
+	return col, nil
 }
 
-// traverseStruct traverses field of kind reflect.Struct and finds columns
-func (cp *cPrinter) traverseStruct(v reflect.Value, fieldIndex ... int) error {
-	val := reflect.ValueOf(v.Interface())
-	if val.Kind() == reflect.Ptr {
-		val = reflect.Indirect(v)
+// traverseOptions holds the options of a traverse tag.
+type traverseOptions struct {
+	explode bool
+	tree    bool
+	prefix  *string
+}
+
+// isTraverseTag reports whether tag is the traverse tag value, with or without options.
+func isTraverseTag(tag string) bool {
+	return tag == TagValueTraverse || strings.HasPrefix(tag, TagValueTraverse+",")
+}
+
+// parseTraverseTag parses the options of a traverse tag.
+func parseTraverseTag(tag string) (traverseOptions, error) {
+	opts := traverseOptions{}
+	for _, opt := range strings.Split(tag, ",")[1:] {
+		switch opt {
+		case TagOptionExplode:
+			opts.explode = true
+		case TagOptionTree:
+			opts.tree = true
+		default:
+			if strings.HasPrefix(opt, TagOptionPrefix) {
+				prefix := strings.TrimPrefix(opt, TagOptionPrefix)
+				opts.prefix = &prefix
+				continue
+			}
+			return opts, fmt.Errorf("unknown option %q", opt)
+		}
 	}
-	if val.Kind() == reflect.Struct {
-		err := cp.findColumns(v.Interface(), fieldIndex...)
-		if err != nil {
-			return err
+	return opts, nil
+}
+
+// selectColumns restricts and orders the columns according to Config.Columns. Returns an error if a label does
+// not match any column.
+func (cp *cPrinter) selectColumns() error {
+	if cp.config.Columns == nil {
+		return nil
+	}
+	selected := columns{}
+	for i, label := range uniqueLabels(cp.config.Columns) {
+		found := false
+		for _, col := range cp.cols {
+			if col.label == label {
+				col.order = i
+				selected = append(selected, col)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("Unknown column %s", label)
 		}
+	}
+	cp.cols = selected
+	return nil
+}
 
+// traverseStruct traverses field of kind reflect.Struct, or pointer to struct, and finds columns. With the
+// explode option, the field must be a slice or array of structs, and the columns of its elements are found.
+// The labels of the found columns are prefixed as given by the prefix option or Config.PathLabels.
+// Recursive struct types, like linked lists, are traversed as deep as the values of the first item go. Returns a
+// TagError if the tag options are invalid or the first item is cyclic.
+func (cp *cPrinter) traverseStruct(field reflect.StructField, fieldIndex ... int) error {
+	tag := field.Tag.Get(TagName)
+	opts, err := parseTraverseTag(tag)
+	if err != nil {
+		return &TagError{Field: field.Name, Tag: tag, Reason: err.Error()}
+	}
+	t := field.Type
+	if opts.tree {
+		if len(fieldIndex) != 1 {
+			return &TagError{Field: field.Name, Tag: tag, Reason: "tree is only supported on fields of the printed struct"}
+		}
+		if t.Kind() != reflect.Slice {
+			return &TagError{Field: field.Name, Tag: tag, Reason: "tree requires a slice of the printed struct"}
+		}
+		cp.tree = &fieldIndex
+		return nil
+	}
+	if opts.explode {
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return &TagError{Field: field.Name, Tag: tag, Reason: "explode requires a slice or array of structs"}
+		}
+		t = t.Elem()
+		fieldIndex = append(fieldIndex, explodeIndex)
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		if opts.explode {
+			return &TagError{Field: field.Name, Tag: tag, Reason: "explode requires a slice or array of structs"}
+		}
+		return nil
+	}
+	if cp.traversing[t] {
+		if tag == TagValueEmpty {
+			// embedded structs are traversed without tags, so recursive embedding is not an error
+			return nil
+		}
+		v, cycle := cp.sampleField(fieldIndex)
+		if cycle {
+			return &TagError{Field: field.Name, Tag: tag, Reason: "cyclic traversal of " + t.String()}
+		}
+		if !itemValue(v).IsValid() {
+			return nil
+		}
+	}
+	n := len(cp.cols)
+	if err := cp.collectColumns(t, fieldIndex...); err != nil {
+		return withFieldPath(field.Name, err)
+	}
+	prefix := ""
+	if opts.prefix != nil {
+		prefix = *opts.prefix
+	} else if *cp.config.PathLabels {
+		prefix = field.Name + "."
+	}
+	for i := n; i < len(cp.cols); i++ {
+		cp.cols[i].label = prefix + cp.cols[i].label
 	}
 	return nil
 }
 
+// sampleField returns the field at fieldIndex in the first item, and whether it points to a struct already passed
+// on the way to it, i.e. whether traversing it would never end. Returns the zero Value if the field is behind a nil
+// pointer or an exploded slice, or if there is no first item.
+func (cp *cPrinter) sampleField(fieldIndex []int) (reflect.Value, bool) {
+	passed := make(map[uintptr]bool)
+	v := cp.sample
+	for _, x := range fieldIndex {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		if !v.IsValid() || x == explodeIndex {
+			return reflect.Value{}, false
+		}
+		if v.CanAddr() {
+			passed[v.Addr().Pointer()] = true
+		}
+		v = v.Field(x)
+	}
+	return v, v.Kind() == reflect.Ptr && !v.IsNil() && passed[v.Pointer()]
+}
+
 // valueOf returns a string representation of a field.
 func (cp *cPrinter) valueOf(i interface{}) string {
 	v := reflect.ValueOf(i)
 	kind := v.Kind()
+	if kind == reflect.Ptr && v.IsNil() {
+		return ""
+	}
+	// Nullable types like sql.NullString print their underlying value
+	if valuer, ok := i.(driver.Valuer); ok {
+		value, err := valuer.Value()
+		if err != nil {
+			return "<Error:" + err.Error() + ">"
+		}
+		return cp.valueOf(value)
+	}
 	switch kind {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
 	case reflect.Array, reflect.Slice:
+		// byte slices like []byte, sql.RawBytes and json.RawMessage print as text
+		if kind == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes())
+		}
 		return cp.valueOfSlice(i)
 	case reflect.Bool:
 		return strconv.FormatBool(v.Bool())
@@ -258,6 +755,12 @@ func (cp *cPrinter) valueOf(i interface{}) string {
 			return ""
 		}
 		return cp.valueOf(reflect.Indirect(v).Interface())
+	case reflect.Struct, reflect.Map:
+		if stringer, ok := i.(fmt.Stringer); ok {
+			return stringer.String()
+		}
+	case reflect.Invalid:
+		return ""
 	}
 	return "<Unsupported kind:" + kind.String() + ">"
 }
@@ -283,9 +786,49 @@ func (cp *cPrinter) valueOfSlice(s interface{}) string {
 func createDefaultConfig() *Config {
 	dMPSI := 3
 	dFP := 2
+	dBRV := false
+	dPL := false
+	dDL := DuplicateLabelsAllow
+	dSE := false
+	dTMD := -1
+	dTCM := " [+]"
+	dPS := 0
+	dMT := MixedTypesUnion
+	dHC := false
+	dC := false
+	dMCW := 0
+	dHSA := false
+	dHD := false
+	dHT := ""
+	dF := "text"
+	dLB := false
+	dRS := false
+	dSD := SQLDialectANSI
+	dSBS := 1
+	dST := ""
 	return &Config{
 		MaxPrintedSliceItems: &dMPSI,
 		FloatPrecision:       &dFP,
+		BlankRepeatedValues:  &dBRV,
+		PathLabels:           &dPL,
+		DuplicateLabels:      &dDL,
+		StrictEmbedding:      &dSE,
+		TreeMaxDepth:         &dTMD,
+		TreeCollapsedMarker:  &dTCM,
+		PageSize:             &dPS,
+		MixedTypes:           &dMT,
+		HighlightChanges:     &dHC,
+		Color:                &dC,
+		MaxColumnWidth:       &dMCW,
+		HTMLSortAttributes:   &dHSA,
+		HTMLDocument:         &dHD,
+		HTMLTitle:            &dHT,
+		Format:               &dF,
+		LaTeXBooktabs:        &dLB,
+		RSTSimple:            &dRS,
+		SQLDialect:           &dSD,
+		SQLBatchSize:         &dSBS,
+		SQLTable:             &dST,
 	}
 }
 
@@ -299,6 +842,122 @@ func mergeConfig(a, c *Config) *Config {
 		if c.FloatPrecision != nil {
 			*a.FloatPrecision = *c.FloatPrecision
 		}
+
+		if c.BlankRepeatedValues != nil {
+			*a.BlankRepeatedValues = *c.BlankRepeatedValues
+		}
+
+		if c.PathLabels != nil {
+			*a.PathLabels = *c.PathLabels
+		}
+
+		if c.DuplicateLabels != nil {
+			*a.DuplicateLabels = *c.DuplicateLabels
+		}
+
+		if c.Warn != nil {
+			a.Warn = c.Warn
+		}
+
+		if c.StrictEmbedding != nil {
+			*a.StrictEmbedding = *c.StrictEmbedding
+		}
+
+		if c.TreeMaxDepth != nil {
+			*a.TreeMaxDepth = *c.TreeMaxDepth
+		}
+
+		if c.TreeCollapsedMarker != nil {
+			*a.TreeCollapsedMarker = *c.TreeCollapsedMarker
+		}
+
+		if c.Computed != nil {
+			a.Computed = c.Computed
+		}
+
+		if c.Templates != nil {
+			a.Templates = c.Templates
+		}
+
+		if c.TemplateFuncs != nil {
+			a.TemplateFuncs = c.TemplateFuncs
+		}
+
+		if c.Paths != nil {
+			a.Paths = c.Paths
+		}
+
+		if c.PageSize != nil {
+			*a.PageSize = *c.PageSize
+		}
+
+		if c.MixedTypes != nil {
+			*a.MixedTypes = *c.MixedTypes
+		}
+
+		if c.HighlightChanges != nil {
+			*a.HighlightChanges = *c.HighlightChanges
+		}
+
+		if c.Color != nil {
+			*a.Color = *c.Color
+		}
+
+		if c.SortBy != nil {
+			a.SortBy = c.SortBy
+		}
+
+		if c.Filters != nil {
+			a.Filters = c.Filters
+		}
+
+		if c.MaxColumnWidth != nil {
+			*a.MaxColumnWidth = *c.MaxColumnWidth
+		}
+
+		if c.HTMLRowClass != nil {
+			a.HTMLRowClass = c.HTMLRowClass
+		}
+
+		if c.HTMLSortAttributes != nil {
+			*a.HTMLSortAttributes = *c.HTMLSortAttributes
+		}
+
+		if c.HTMLDocument != nil {
+			*a.HTMLDocument = *c.HTMLDocument
+		}
+
+		if c.HTMLTitle != nil {
+			*a.HTMLTitle = *c.HTMLTitle
+		}
+
+		if c.Format != nil {
+			*a.Format = *c.Format
+		}
+
+		if c.LaTeXBooktabs != nil {
+			*a.LaTeXBooktabs = *c.LaTeXBooktabs
+		}
+
+		if c.RSTSimple != nil {
+			*a.RSTSimple = *c.RSTSimple
+		}
+
+		if c.SQLDialect != nil {
+			*a.SQLDialect = *c.SQLDialect
+		}
+
+		if c.SQLBatchSize != nil {
+			*a.SQLBatchSize = *c.SQLBatchSize
+		}
+
+		if c.SQLTable != nil {
+			*a.SQLTable = *c.SQLTable
+		}
+
+		if c.Columns != nil {
+			a.Columns = c.Columns
+		}
 	}
 	return a
 }
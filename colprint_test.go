@@ -5,6 +5,7 @@ import (
 	"testing"
 	"os"
 	"errors"
+	"bytes"
 )
 
 type UnitTests struct {
@@ -64,7 +65,7 @@ func (s *UnitTests) TestCPrinter_init() {
 func (s *UnitTests) TestCPrinter_initColumn() {
 	cp := cPrinter{}
 	cp.init()
-	col := column{&[]int{}, "label", 2}
+	col := column{fieldIndex: &[]int{}, label: "label", order: 2}
 	val := cp.values[col]
 	s.Nil(val)
 	cp.initColumn(col)
@@ -102,6 +103,10 @@ func (s *UnitTests) TestMergeConfig() {
 	mergedConf = mergeConfig(defaultConf, &Config{FloatPrecision: &fp})
 	s.Equal(fp, *mergedConf.FloatPrecision)
 	s.Equal(*defaultConf.MaxPrintedSliceItems, *mergedConf.MaxPrintedSliceItems)
+
+	mt := MixedTypesSeparate
+	mergedConf = mergeConfig(defaultConf, &Config{MixedTypes: &mt})
+	s.Equal(mt, *mergedConf.MixedTypes)
 }
 
 func (s *UnitTests) TestCreateDefaultConfig() {
@@ -109,7 +114,9 @@ func (s *UnitTests) TestCreateDefaultConfig() {
 	s.NotNil(c)
 	s.NotNil(c.MaxPrintedSliceItems)
 	s.NotNil(c.FloatPrecision)
+	s.NotNil(c.MixedTypes)
 	s.Equal(2, *c.FloatPrecision)
+	s.Equal(MixedTypesUnion, *c.MixedTypes)
 	s.Equal(3, *c.MaxPrintedSliceItems)
 }
 
@@ -221,6 +228,15 @@ func (s *UnitTests) TestPrint_CompositionWithErrors() {
 	s.Error(Print(C{B: &B{Date: "29.03.2017", A: A{Name: "Kari Nordmann"}}, Description:"desc"}))
 }
 
+func (s *UnitTests) TestSprint_WithColumns() {
+	d := DummyData{Name: "name", Description: "description", Version: float32(35)}
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, d, &Config{Columns: []string{"Description", "Name"}}))
+	s.Equal("Description  Name\ndescription  name", buf.String())
+
+	s.Error(Fprint(buf, d, &Config{Columns: []string{"Unknown"}}))
+}
+
 type Errornous struct {
 	Error error `colprint:"Error,a"`
 }
@@ -245,3 +261,15 @@ type Person struct {
 }
 
 
+
+type dummyLabels map[string]string
+
+func (l dummyLabels) String() string {
+	return "labels"
+}
+
+func (s *UnitTests) TestValueOf_MapStringer() {
+	cp := cPrinter{config: createDefaultConfig()}
+	s.Equal("labels", cp.valueOf(dummyLabels{"a": "b"}))
+	s.Equal("<Unsupported kind:map>", cp.valueOf(map[string]string{}))
+}
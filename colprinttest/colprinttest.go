@@ -0,0 +1,130 @@
+// Package colprinttest provides helpers for testing colprint output against golden files.
+//
+// Golden files are stored under testdata, and are updated instead of compared when the tests are run with the
+// -update flag, or with the COLPRINTTEST_UPDATE environment variable set:
+//
+//	go test ./mypackage -update
+//
+// The package defines the -update flag unless a flag of that name is already defined.
+package colprinttest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/peteabre/colprint"
+)
+
+// UpdateFlag is the name of the flag updating golden files instead of comparing them.
+const UpdateFlag = "update"
+
+// UpdateEnv is the name of the environment variable updating golden files instead of comparing them when set.
+const UpdateEnv = "COLPRINTTEST_UPDATE"
+
+func init() {
+	if flag.Lookup(UpdateFlag) == nil {
+		flag.Bool(UpdateFlag, false, "update golden files")
+	}
+}
+
+// updating reports whether the -update flag or the COLPRINTTEST_UPDATE environment variable is set.
+func updating() bool {
+	if f := flag.Lookup(UpdateFlag); f != nil && f.Value.String() == "true" {
+		return true
+	}
+	return os.Getenv(UpdateEnv) != ""
+}
+
+// Render prints v using provided config, and returns the output. Fails the test if printing fails.
+func Render(t testing.TB, v interface{}, c ...*colprint.Config) string {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := colprint.Fprint(buf, v, c...); err != nil {
+		t.Fatalf("colprinttest: cannot print %T: %v", v, err)
+	}
+	return buf.String()
+}
+
+// Golden prints v using provided config, and compares the output to the golden file testdata/<name>.golden.
+func Golden(t testing.TB, name string, v interface{}, c ...*colprint.Config) {
+	t.Helper()
+	AssertGolden(t, name, Render(t, v, c...))
+}
+
+// AssertGolden compares got to the golden file testdata/<name>.golden, ignoring trailing whitespace. The
+// differences are reported by row and column. When the -update flag is set, the golden file is written instead.
+func AssertGolden(t testing.TB, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("colprinttest: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(Normalize(got)+"\n"), 0644); err != nil {
+			t.Fatalf("colprinttest: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("colprinttest: %v (run with -%s to create it)", err, UpdateFlag)
+	}
+	if diff := Diff(string(want), got); diff != "" {
+		t.Errorf("colprinttest: output does not match %s (run with -%s to update it):\n%s\ngot:\n%s",
+			path, UpdateFlag, diff, Normalize(got))
+	}
+}
+
+// Normalize removes the trailing whitespace of each line, and the trailing blank lines.
+func Normalize(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// Diff compares two printed tables, ignoring trailing whitespace, and returns their differences, or an empty
+// string if they are equal. Differences are reported by row, starting from 1 for the first row after the header,
+// and by column label, with the columns split at the positions of the labels in the header lines.
+func Diff(want, got string) string {
+	wantLines := strings.Split(Normalize(want), "\n")
+	gotLines := strings.Split(Normalize(got), "\n")
+	diffs := []string{}
+	if wantLines[0] != gotLines[0] {
+		diffs = append(diffs, fmt.Sprintf("header: want %q, got %q", wantLines[0], gotLines[0]))
+	}
+	labels, wantStarts := colprint.SplitHeader(wantLines[0])
+	_, gotStarts := colprint.SplitHeader(gotLines[0])
+	for i := 1; i < len(wantLines) || i < len(gotLines); i++ {
+		switch {
+		case i >= len(gotLines):
+			diffs = append(diffs, fmt.Sprintf("row %d: missing %q", i, wantLines[i]))
+		case i >= len(wantLines):
+			diffs = append(diffs, fmt.Sprintf("row %d: unexpected %q", i, gotLines[i]))
+		case wantLines[i] != gotLines[i]:
+			wantCells := colprint.SplitCells(wantLines[i], wantStarts)
+			gotCells := colprint.SplitCells(gotLines[i], gotStarts)
+			if len(wantCells) != len(gotCells) {
+				diffs = append(diffs, fmt.Sprintf("row %d: want %q, got %q", i, wantLines[i], gotLines[i]))
+				continue
+			}
+			for j := range wantCells {
+				if wantCells[j] != gotCells[j] {
+					diffs = append(diffs, fmt.Sprintf("row %d, column %s: want %q, got %q",
+						i, labels[j], wantCells[j], gotCells[j]))
+				}
+			}
+			if strings.Join(wantCells, "") == strings.Join(gotCells, "") {
+				// the cells are equal, but aligned differently
+				diffs = append(diffs, fmt.Sprintf("row %d: want %q, got %q", i, wantLines[i], gotLines[i]))
+			}
+		}
+	}
+	return strings.Join(diffs, "\n")
+}
@@ -0,0 +1,75 @@
+package colprinttest
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type UnitTests struct {
+	suite.Suite
+}
+
+func TestRunUnitTests(t *testing.T) {
+	suite.Run(t, new(UnitTests))
+}
+
+type dummyService struct {
+	Name  string `colprint:"Name,1"`
+	State string `colprint:"State,2"`
+	Port  int    `colprint:"Port,3"`
+}
+
+var services = []dummyService{{"web", "up", 80}, {"db", "starting", 5432}}
+
+// recorder records the failures of a test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (s *UnitTests) TestGolden() {
+	Golden(s.T(), "services", services)
+}
+
+func (s *UnitTests) TestAssertGolden_Mismatch() {
+	if updating() {
+		s.T().Skip("would overwrite the golden file")
+	}
+	r := &recorder{TB: s.T()}
+	AssertGolden(r, "services", Render(s.T(), []dummyService{{"web", "down", 80}}))
+	s.Len(r.errors, 1)
+	s.Contains(r.errors[0], `row 1, column State: want "up", got "down"`)
+	s.Contains(r.errors[0], `row 2: missing "db    starting  5432"`)
+}
+
+func (s *UnitTests) TestUpdating() {
+	if flag.Lookup(UpdateFlag).Value.String() == "true" {
+		s.T().Skip("-update is set")
+	}
+	s.T().Setenv(UpdateEnv, "")
+	s.False(updating())
+	s.T().Setenv(UpdateEnv, "1")
+	s.True(updating())
+}
+
+func (s *UnitTests) TestNormalize() {
+	s.Equal("a\n b", Normalize("a  \r\n b\t\n\n"))
+}
+
+func (s *UnitTests) TestDiff() {
+	s.Equal("", Diff("Name  Port\nweb   80  \n", "Name  Port\nweb   80"))
+	s.Equal(`header: want "Name  Port", got "Name  Ports"`, Diff("Name  Port", "Name  Ports"))
+	s.Equal(`row 1, column Port: want "80", got "8080"`+"\n"+`row 2: unexpected "db    5432"`,
+		Diff("Name  Port\nweb   80", "Name  Port\nweb   8080\ndb    5432"))
+	s.Equal(`row 1, column Name: want "web", got "web  8"`+"\n"+`row 1, column Port: want "80", got "0"`,
+		Diff("Name  Port\nweb   80", "Name  Port\nweb  80"))
+}
@@ -0,0 +1,135 @@
+package colprint
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ComputedColumn is a column computed from the printed structs rather than read from a field.
+type ComputedColumn struct {
+	// Tag is the label and order of the column, in the same format as the colprint tag, e.g. "Age,3".
+	Tag string
+	// Func returns the value of the column for a struct.
+	Func func(item interface{}) interface{}
+}
+
+// computer computes the value of a column from a struct.
+type computer struct {
+	// name is the name of the method or computed column, used when reporting errors
+	name string
+	fn   func(v reflect.Value) interface{}
+}
+
+// methodColumn is a method registered as a column.
+type methodColumn struct {
+	method string
+	col    column
+}
+
+var (
+	methodsMu sync.RWMutex
+	methods   = make(map[reflect.Type][]methodColumn)
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// RegisterMethod registers a method of a struct type as a column, printed wherever the struct is printed like a
+// tagged field. The method must take no arguments, and return a value and optionally an error. The tag holds the
+// label and order of the column, in the same format as the colprint tag, e.g. "Ready,3".
+func RegisterMethod(s interface{}, method string, tag string) error {
+	t := reflect.TypeOf(s)
+	if t == nil {
+		return ErrNilValue
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if err := checkStruct(t); err != nil {
+		return err
+	}
+	m, ok := reflect.PtrTo(t).MethodByName(method)
+	if !ok {
+		return fmt.Errorf("Unknown method %s on %s", method, t)
+	}
+	mt := m.Type
+	if mt.NumIn() != 1 || mt.NumOut() < 1 || mt.NumOut() > 2 || (mt.NumOut() == 2 && mt.Out(1) != errorType) {
+		return fmt.Errorf("Invalid signature of method %s on %s", method, t)
+	}
+	col, err := parseColumnTag(tag)
+	if err != nil {
+		return &TagError{Field: method, Tag: tag, Reason: err.Error()}
+	}
+
+	methodsMu.Lock()
+	defer methodsMu.Unlock()
+	registered := methods[t][:0:0]
+	for _, mc := range methods[t] {
+		if mc.method != method {
+			registered = append(registered, mc)
+		}
+	}
+	methods[t] = append(registered, methodColumn{method: method, col: col})
+	return nil
+}
+
+// appendMethodColumns appends the methods registered on struct type t to the list of columns.
+func (cp *cPrinter) appendMethodColumns(t reflect.Type, fieldIndex []int) {
+	methodsMu.RLock()
+	defer methodsMu.RUnlock()
+	for _, mc := range methods[t] {
+		index := append([]int{}, fieldIndex...)
+		col := mc.col
+		col.fieldIndex = &index
+		col.compute = &computer{name: mc.method, fn: methodFunc(mc.method)}
+		cp.cols = append(cp.cols, col)
+	}
+}
+
+// methodFunc returns a function calling the named method on a struct. Methods with pointer receivers are called
+// on a copy if the struct is not addressable.
+func methodFunc(method string) func(v reflect.Value) interface{} {
+	return func(v reflect.Value) interface{} {
+		m := v.MethodByName(method)
+		if !m.IsValid() {
+			if !v.CanAddr() {
+				p := reflect.New(v.Type())
+				p.Elem().Set(v)
+				v = p.Elem()
+			}
+			m = v.Addr().MethodByName(method)
+		}
+		out := m.Call(nil)
+		if len(out) == 2 && !out[1].IsNil() {
+			return "<Error:" + out[1].Interface().(error).Error() + ">"
+		}
+		return out[0].Interface()
+	}
+}
+
+// appendComputedColumns appends the columns of Config.Computed to the list of columns.
+func (cp *cPrinter) appendComputedColumns() error {
+	for i, c := range cp.config.Computed {
+		col, err := parseColumnTag(c.Tag)
+		if err != nil {
+			return &TagError{Field: fmt.Sprintf("Computed[%d]", i), Tag: c.Tag, Reason: err.Error()}
+		}
+		fn := c.Func
+		col.fieldIndex = &[]int{}
+		col.compute = &computer{name: col.label, fn: func(v reflect.Value) interface{} {
+			return fn(v.Interface())
+		}}
+		cp.cols = append(cp.cols, col)
+	}
+	return nil
+}
+
+// path returns the dotted path of the field or method of the column in struct type t.
+func (c column) path(t reflect.Type) string {
+	path := fieldPath(t, *c.fieldIndex)
+	if c.compute == nil {
+		return path
+	}
+	return strings.TrimPrefix(path+"."+c.compute.name, ".")
+}
@@ -0,0 +1,78 @@
+package colprint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+type dummyDeploy struct {
+	Name    string `colprint:"Name,1"`
+	Ready   int
+	Desired int
+}
+
+func (d dummyDeploy) ReadyString() string {
+	return fmt.Sprintf("%d/%d", d.Ready, d.Desired)
+}
+
+func (d *dummyDeploy) Healthy() (bool, error) {
+	if d.Desired == 0 {
+		return false, errors.New("scaled down")
+	}
+	return d.Ready == d.Desired, nil
+}
+
+type dummyDeployList struct {
+	Namespace string        `colprint:"Namespace,1"`
+	Deploys   []dummyDeploy `colprint:"=>,explode"`
+}
+
+func (s *UnitTests) TestRegisterMethod() {
+	s.NoError(RegisterMethod(dummyDeploy{}, "ReadyString", "Ready,2"))
+	s.NoError(RegisterMethod(&dummyDeploy{}, "Healthy", "Healthy,3"))
+	defer func() {
+		methodsMu.Lock()
+		delete(methods, reflect.TypeOf(dummyDeploy{}))
+		methodsMu.Unlock()
+	}()
+
+	val, err := Sprint([]dummyDeploy{{Name: "web", Ready: 2, Desired: 3}, {Name: "db"}})
+	s.NoError(err)
+	s.Equal("Name  Ready  Healthy\nweb   2/3    false\ndb    0/0    <Error:scaled down>", val)
+
+	val, err = Sprint(dummyDeployList{Namespace: "prod", Deploys: []dummyDeploy{{Name: "web", Ready: 1, Desired: 1}}})
+	s.NoError(err)
+	s.Equal("Namespace  Name  Ready  Healthy\nprod       web   1/1    true", val)
+}
+
+func (s *UnitTests) TestRegisterMethod_Errors() {
+	s.Error(RegisterMethod(dummyDeploy{}, "Unknown", "Label"))
+	s.Error(RegisterMethod(dummyDeploy{}, "Name", "Label"))
+	s.True(errors.Is(RegisterMethod(42, "String", "Label"), ErrNotStruct))
+	s.True(errors.Is(RegisterMethod(nil, "String", "Label"), ErrNilValue))
+
+	var tagErr *TagError
+	s.True(errors.As(RegisterMethod(dummyDeploy{}, "ReadyString", "Ready,a"), &tagErr))
+	s.Equal("ReadyString", tagErr.Field)
+}
+
+func (s *UnitTests) TestFprint_ComputedColumns() {
+	computed := []ComputedColumn{
+		{Tag: "Ready,2", Func: func(item interface{}) interface{} {
+			d := item.(dummyDeploy)
+			return fmt.Sprintf("%d/%d", d.Ready, d.Desired)
+		}},
+		{Tag: "Scaled", Func: func(item interface{}) interface{} {
+			return item.(dummyDeploy).Desired > 0
+		}},
+	}
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, []*dummyDeploy{{Name: "web", Ready: 2, Desired: 3}, nil}, &Config{Computed: computed}))
+	s.Equal("Name  Ready  Scaled\nweb   2/3    true\n             ", buf.String())
+
+	var tagErr *TagError
+	computed = []ComputedColumn{{Tag: "Ready,a", Func: func(item interface{}) interface{} { return nil }}}
+	s.True(errors.As(Fprint(buf, dummyDeploy{}, &Config{Computed: computed}), &tagErr))
+}
@@ -0,0 +1,54 @@
+package colprint
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+)
+
+// FprintCSV prints struct or slice to provided io.Writer as CSV using provided config, with a header record of
+// the column labels. Items of different struct types printed in separate tables are separated by blank lines.
+// If config is nil, default config will be used.
+func FprintCSV(w io.Writer, s interface{}, c ...*Config) error {
+	return fprintRendered(w, s, csvRenderer{}, c...)
+}
+
+// csvRenderer renders tables as CSV records, each table starting with a header record and separated by blank
+// lines.
+type csvRenderer struct{}
+
+func (csvRenderer) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (r csvRenderer) Render(w io.Writer, t *TableModel) error {
+	return r.RenderTables(w, []*TableModel{t})
+}
+
+func (csvRenderer) RenderTables(w io.Writer, tables []*TableModel) error {
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	for i, t := range tables {
+		if i > 0 {
+			cw.Flush()
+			buf.WriteString("\n")
+		}
+		headers := make([]string, len(t.Columns))
+		for j, col := range t.Columns {
+			headers[j] = col.Label
+		}
+		if err := cw.Write(headers); err != nil {
+			return err
+		}
+		for _, row := range t.Rows {
+			if err := cw.Write(row.Values); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+	return writeString(w, buf.String())
+}
@@ -0,0 +1,19 @@
+package colprint
+
+import (
+	"bytes"
+)
+
+func (s *UnitTests) TestFprintCSV() {
+	hosts := []dummyHost{{Name: "web, \"primary\"", Load: 0.375}, {Name: "db", Load: 2}}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintCSV(buf, hosts))
+	s.Equal("Name,Load\n\"web, \"\"primary\"\"\",0.38\ndb,2.00\n", buf.String())
+
+	buf.Reset()
+	mt := MixedTypesSeparate
+	s.NoError(FprintCSV(buf, []interface{}{dummyHost{Name: "web"}, dummyProcess{Name: "nginx"}}, &Config{MixedTypes: &mt}))
+	s.Equal("Name,Load\nweb,0.00\n\nName,State\nnginx,\n", buf.String())
+
+	s.Equal(ErrNilValue, FprintCSV(buf, nil))
+}
@@ -0,0 +1,265 @@
+package colprint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"unicode/utf8"
+)
+
+// Markers of the rows of a diff.
+const (
+	DiffAdded    = "+"
+	DiffRemoved  = "-"
+	DiffModified = "~"
+)
+
+// diffArrow separates the old and new values of changed cells in plain diffs.
+const diffArrow = "→"
+
+// ANSI escape sequences used to color diffs.
+const (
+	colorAdded    = "\x1b[32m"
+	colorRemoved  = "\x1b[31m"
+	colorModified = "\x1b[33m"
+)
+
+// diffRow is a row of a diff, holding the marker and cells of the row, and which cells changed.
+type diffRow struct {
+	marker  string
+	cells   []string
+	changed []bool
+}
+
+// FprintDiff prints the differences between two slices of the same struct type to provided io.Writer using
+// provided config. Rows are matched by the values of the column labeled key, which must be unique. Columns are
+// matched by label, so that columns found only before or after, e.g. map keys, are printed blank where missing. Added, removed
+// and modified rows are marked with DiffAdded, DiffRemoved and DiffModified, while unchanged rows are left out.
+// Added and modified rows are printed in the order of after, followed by the removed rows in the order of before.
+// The changed cells of modified rows are printed as old→new, or highlighted if Config.Color is set.
+// If config is nil, default config will be used.
+func FprintDiff(w io.Writer, before, after interface{}, key string, c ...*Config) error {
+	if before == nil || after == nil {
+		return ErrNilValue
+	}
+	if bt, at := reflect.TypeOf(before), reflect.TypeOf(after); bt != at {
+		return fmt.Errorf("Cannot diff %s and %s: different types", bt, at)
+	}
+	old, err := loadTable(before, c...)
+	if err != nil {
+		return err
+	}
+	cp, err := loadTable(after, c...)
+	if err != nil {
+		return err
+	}
+	cols := diffColumns(old, cp)
+	keyCol := -1
+	for i, col := range cols {
+		if col.label == key && keyCol < 0 {
+			keyCol = i
+		}
+	}
+	if keyCol < 0 {
+		return fmt.Errorf("Unknown key column %s", key)
+	}
+
+	oldRows, err := old.rowsByKey(cols[keyCol].before, key)
+	if err != nil {
+		return err
+	}
+	newRows, err := cp.rowsByKey(cols[keyCol].after, key)
+	if err != nil {
+		return err
+	}
+	rows := []diffRow{}
+	for i := 0; i < cp.itemCount; i++ {
+		cells := cp.diffCells(i, cols, false)
+		j, ok := oldRows[cells[keyCol]]
+		if !ok {
+			rows = append(rows, diffRow{marker: DiffAdded, cells: cells})
+			continue
+		}
+		if row, modified := cp.compareCells(old.diffCells(j, cols, true), cells); modified {
+			rows = append(rows, row)
+		}
+	}
+	for j := 0; j < old.itemCount; j++ {
+		cells := old.diffCells(j, cols, true)
+		if _, ok := newRows[cells[keyCol]]; !ok {
+			rows = append(rows, diffRow{marker: DiffRemoved, cells: cells})
+		}
+	}
+	return cp.writeDiff(newLineWriter(w), cols, rows)
+}
+
+// SprintDiff returns the differences between two slices of the same struct type, as printed by FprintDiff.
+func SprintDiff(before, after interface{}, key string, c ...*Config) (string, error) {
+	buf := new(bytes.Buffer)
+	err := FprintDiff(buf, before, after, key, c...)
+	return buf.String(), err
+}
+
+// loadTable adds a struct or slice to a cPrinter using provided config. Returns an error if the items would be
+// printed in more than one table.
+func loadTable(s interface{}, c ...*Config) (*cPrinter, error) {
+	printers, err := load(s, c...)
+	if err != nil {
+		return nil, err
+	}
+	if len(printers) != 1 {
+		return nil, fmt.Errorf("Cannot print %T as a single table", s)
+	}
+	return printers[0], nil
+}
+
+// row returns the values of row i.
+func (cp *cPrinter) row(i int) []string {
+	cells := make([]string, len(cp.cols))
+	for j, col := range cp.cols {
+		cells[j] = cp.values[col][i]
+	}
+	return cells
+}
+
+// diffColumn is a column of a diff, and its positions in the tables before and after, or -1 if the table does not
+// have it.
+type diffColumn struct {
+	label  string
+	before int
+	after  int
+}
+
+// diffColumns matches the columns of the tables before and after by label, the nth column of a label before
+// matching the nth column of that label after. Returns the columns after, followed by the columns only found
+// before, e.g. keys of maps that were removed.
+func diffColumns(before, after *cPrinter) []diffColumn {
+	matched := make([]bool, len(before.cols))
+	cols := []diffColumn{}
+	for i, col := range after.cols {
+		dc := diffColumn{label: col.label, before: -1, after: i}
+		for j, beforeCol := range before.cols {
+			if !matched[j] && beforeCol.label == col.label {
+				matched[j] = true
+				dc.before = j
+				break
+			}
+		}
+		cols = append(cols, dc)
+	}
+	for j, col := range before.cols {
+		if !matched[j] {
+			cols = append(cols, diffColumn{label: col.label, before: j, after: -1})
+		}
+	}
+	return cols
+}
+
+// diffCells returns the values of row i in the order of the diff columns, with blanks for the columns the table
+// does not have. The table is the table before if before is set, and otherwise the table after.
+func (cp *cPrinter) diffCells(i int, cols []diffColumn, before bool) []string {
+	row := cp.row(i)
+	cells := make([]string, len(cols))
+	for j, col := range cols {
+		index := col.after
+		if before {
+			index = col.before
+		}
+		if index >= 0 {
+			cells[j] = row[index]
+		}
+	}
+	return cells
+}
+
+// rowsByKey returns the index of each row by the value of its cell in column keyCol, labeled key. Returns an error
+// if a value is found in more than one row, or if the table has rows but no column keyCol.
+func (cp *cPrinter) rowsByKey(keyCol int, key string) (map[string]int, error) {
+	rows := make(map[string]int)
+	if keyCol < 0 {
+		if cp.itemCount > 0 {
+			return nil, fmt.Errorf("Unknown key column %s", key)
+		}
+		return rows, nil
+	}
+	values := cp.values[cp.cols[keyCol]]
+	for i, val := range values {
+		if _, ok := rows[val]; ok {
+			return nil, fmt.Errorf("Duplicate key %q in column %s", val, key)
+		}
+		rows[val] = i
+	}
+	return rows, nil
+}
+
+// compareCells compares the cells of a row before and after, and returns the modified row, showing changed cells as
+// old→new unless colored. Reports whether any cell changed.
+func (cp *cPrinter) compareCells(before, after []string) (diffRow, bool) {
+	row := diffRow{marker: DiffModified, cells: after, changed: make([]bool, len(after))}
+	modified := false
+	for i := range after {
+		if before[i] == after[i] {
+			continue
+		}
+		modified = true
+		row.changed[i] = true
+		if !*cp.config.Color {
+			row.cells[i] = before[i] + diffArrow + after[i]
+		}
+	}
+	return row, modified
+}
+
+// writeDiff writes the header line followed by the rows of a diff, each starting with its marker.
+func (cp *cPrinter) writeDiff(lw *lineWriter, cols []diffColumn, rows []diffRow) error {
+	// the marker column has a blank header
+	headers := []string{" "}
+	for _, col := range cols {
+		headers = append(headers, col.label)
+	}
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = utf8.RuneCountInString(header)
+	}
+	lines := [][]string{}
+	for _, row := range rows {
+		line := append([]string{row.marker}, row.cells...)
+		for i, val := range line {
+			if l := utf8.RuneCountInString(val); l > widths[i] {
+				widths[i] = l
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	if err := lw.writeLine(formatLine(headers, widths)); err != nil {
+		return err
+	}
+	for i, line := range lines {
+		if err := lw.writeLine(formatStyledLine(line, widths, cp.diffStyles(rows[i]))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffStyles returns the styles of the marker and cells of a row of a diff: added rows are green, removed rows red,
+// and the marker and changed cells of modified rows yellow. Returns nil unless Config.Color is set.
+func (cp *cPrinter) diffStyles(row diffRow) []string {
+	if !*cp.config.Color {
+		return nil
+	}
+	styles := make([]string, len(row.cells)+1)
+	for i := range styles {
+		switch {
+		case row.marker == DiffAdded:
+			styles[i] = colorAdded
+		case row.marker == DiffRemoved:
+			styles[i] = colorRemoved
+		case i == 0 || row.changed[i-1]:
+			styles[i] = colorModified
+		}
+	}
+	return styles
+}
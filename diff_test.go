@@ -0,0 +1,60 @@
+package colprint
+
+var diffBefore = []dummyProcess{{"web", "up"}, {"db", "up"}, {"cache", "up"}}
+var diffAfter = []dummyProcess{{"web", "up"}, {"db", "down"}, {"queue", "starting"}}
+
+func (s *UnitTests) TestSprintDiff() {
+	out, err := SprintDiff(diffBefore, diffAfter, "Name")
+	s.NoError(err)
+	s.Equal("   Name   State\n"+
+		"~  db     up→down\n"+
+		"+  queue  starting\n"+
+		"-  cache  up", out)
+
+	out, err = SprintDiff(diffBefore, diffBefore, "Name")
+	s.NoError(err)
+	s.Equal("   Name  State", out)
+}
+
+func (s *UnitTests) TestSprintDiff_Maps() {
+	out, err := SprintDiff([]map[string]interface{}{{"name": "a"}}, []map[string]interface{}{{"name": "a", "x": 1}},
+		"name")
+	s.NoError(err)
+	s.Equal("   name  x\n~  a     →1", out)
+
+	out, err = SprintDiff([]map[string]interface{}{{"name": "a", "x": 1}, {"name": "b", "y": 2}},
+		[]map[string]interface{}{{"x": 1, "name": "a"}}, "name")
+	s.NoError(err)
+	s.Equal("   name  x  y\n-  b        2", out)
+
+	out, err = SprintDiff([]map[string]interface{}{}, []map[string]interface{}{{"name": "a"}}, "name")
+	s.NoError(err)
+	s.Equal("   name\n+  a", out)
+
+	_, err = SprintDiff([]map[string]interface{}{{"id": "a"}}, []map[string]interface{}{{"name": "a"}}, "name")
+	s.EqualError(err, "Unknown key column name")
+}
+
+func (s *UnitTests) TestSprintDiff_Color() {
+	color := true
+	out, err := SprintDiff(diffBefore, diffAfter, "Name", &Config{Color: &color})
+	s.NoError(err)
+	s.Equal("   Name   State\n"+
+		"\x1b[33m~\x1b[0m  db     \x1b[33mdown\x1b[0m\n"+
+		"\x1b[32m+\x1b[0m  \x1b[32mqueue\x1b[0m  \x1b[32mstarting\x1b[0m\n"+
+		"\x1b[31m-\x1b[0m  \x1b[31mcache\x1b[0m  \x1b[31mup\x1b[0m", out)
+}
+
+func (s *UnitTests) TestSprintDiff_Errors() {
+	_, err := SprintDiff(diffBefore, diffAfter, "Unknown")
+	s.EqualError(err, "Unknown key column Unknown")
+
+	_, err = SprintDiff(diffBefore, []dummyServer{}, "Name")
+	s.Error(err)
+
+	_, err = SprintDiff(diffBefore, nil, "Name")
+	s.Equal(ErrNilValue, err)
+
+	_, err = SprintDiff(diffBefore, []dummyProcess{{"web", "up"}, {"web", "down"}}, "Name")
+	s.EqualError(err, `Duplicate key "web" in column Name`)
+}
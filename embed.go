@@ -0,0 +1,46 @@
+package colprint
+
+import (
+	"reflect"
+)
+
+// promoteColumns finds the columns of an untagged anonymous embedded struct field of struct type t, keeping only
+// the columns of fields promoted to t by Go's field shadowing rules.
+func (cp *cPrinter) promoteColumns(t reflect.Type, field reflect.StructField, fieldIndex ...int) error {
+	n := len(cp.cols)
+	if err := cp.traverseStruct(field, fieldIndex...); err != nil {
+		return err
+	}
+	base := len(fieldIndex) - 1
+	promoted := cp.cols[:n]
+	for _, col := range cp.cols[n:] {
+		if isPromoted(t, (*col.fieldIndex)[base:]) {
+			promoted = append(promoted, col)
+		}
+	}
+	cp.cols = promoted
+	return nil
+}
+
+// isPromoted reports whether the field at index in struct type t is promoted, i.e. not shadowed by a field of the
+// same name at a shallower depth, nor ambiguous with a field of the same name at the same depth. Fields reached
+// through named fields are not promoted by Go, and are always kept.
+func isPromoted(t reflect.Type, index []int) bool {
+	st := t
+	var field reflect.StructField
+	for i, x := range index {
+		if st.Kind() == reflect.Ptr {
+			st = st.Elem()
+		}
+		if x == explodeIndex {
+			return true
+		}
+		field = st.Field(x)
+		if i < len(index)-1 && !field.Anonymous {
+			return true
+		}
+		st = field.Type
+	}
+	visible, ok := t.FieldByName(field.Name)
+	return ok && reflect.DeepEqual(visible.Index, index)
+}
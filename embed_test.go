@@ -0,0 +1,71 @@
+package colprint
+
+import (
+	"bytes"
+	"reflect"
+)
+
+type DummyMetadata struct {
+	Name    string `colprint:"Name,1"`
+	Created string `colprint:"Created,3"`
+}
+
+type DummyLabels struct {
+	Name  string `colprint:"Label name,4"`
+	Owner string `colprint:"Owner,5"`
+}
+
+type dummyResource struct {
+	DummyMetadata
+	Kind string `colprint:"Kind,2"`
+}
+
+type dummyShadowed struct {
+	*DummyMetadata
+	DummyLabels
+	Owner string `colprint:"Resource owner,6"`
+}
+
+type dummyRecursiveEmbed struct {
+	*dummyRecursiveEmbed
+	Name string `colprint:"Name"`
+}
+
+func (s *UnitTests) TestSprint_EmbeddedStruct() {
+	val, err := Sprint([]dummyResource{{DummyMetadata: DummyMetadata{Name: "web", Created: "today"}, Kind: "Pod"}})
+	s.NoError(err)
+	s.Equal("Name  Kind  Created\nweb   Pod   today", val)
+}
+
+func (s *UnitTests) TestSprint_EmbeddedShadowing() {
+	// Name is ambiguous between the embedded structs, and Owner is shadowed by the outer field
+	items := []dummyShadowed{
+		{DummyMetadata: &DummyMetadata{Created: "today"}, DummyLabels: DummyLabels{Owner: "Ola"}, Owner: "Kari"},
+		{Owner: "Kari"},
+	}
+	val, err := Sprint(items)
+	s.NoError(err)
+	s.Equal("Created  Resource owner\ntoday    Kari\n         Kari", val)
+}
+
+func (s *UnitTests) TestSprint_RecursiveEmbedding() {
+	val, err := Sprint(dummyRecursiveEmbed{Name: "root"})
+	s.NoError(err)
+	s.Equal("Name\nroot", val)
+}
+
+func (s *UnitTests) TestFprint_StrictEmbedding() {
+	strict := true
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, dummyResource{Kind: "Pod"}, &Config{StrictEmbedding: &strict}))
+	s.Equal("Kind\nPod", buf.String())
+}
+
+func (s *UnitTests) TestIsPromoted() {
+	t := reflect.TypeOf(dummyShadowed{})
+	s.True(isPromoted(t, []int{0, 1}))
+	s.False(isPromoted(t, []int{0, 0}))
+	s.False(isPromoted(t, []int{1, 0}))
+	s.False(isPromoted(t, []int{1, 1}))
+	s.True(isPromoted(t, []int{2}))
+}
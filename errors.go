@@ -0,0 +1,106 @@
+package colprint
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrNilValue is returned when the value to print is nil or a nil pointer.
+var ErrNilValue = errors.New("colprint: nil value")
+
+// ErrNotStruct is returned when the value to print, or an item in the slice to print, is not a struct.
+// The returned error wraps ErrNotStruct with the offending type, and can be checked with errors.Is.
+var ErrNotStruct = errors.New("colprint: not a struct")
+
+// ErrGroupedRows is returned when Config.SortBy or Config.Filters is used in tree mode or with exploded columns,
+// whose rows belong together and cannot be reordered or dropped one by one.
+var ErrGroupedRows = errors.New("colprint: cannot sort or filter rows of trees or exploded items")
+
+// TagError is returned when a field has an invalid colprint tag.
+type TagError struct {
+	// Field is the dotted path of the field, starting from the printed struct.
+	Field string
+	// Tag is the tag text of the field.
+	Tag string
+	// Reason describes what is wrong with the tag.
+	Reason string
+}
+
+func (e *TagError) Error() string {
+	return fmt.Sprintf("Invalid tag %q on field %s: %s", e.Tag, e.Field, e.Reason)
+}
+
+// FieldAccessError is returned when the value of a tagged field cannot be read.
+type FieldAccessError struct {
+	// Field is the dotted path of the field, starting from the printed struct.
+	Field string
+	// Reason describes why the field could not be read.
+	Reason string
+}
+
+func (e *FieldAccessError) Error() string {
+	return fmt.Sprintf("Cannot access field %s: %s", e.Field, e.Reason)
+}
+
+// notStructError returns ErrNotStruct wrapped with the offending type.
+func notStructError(t reflect.Type) error {
+	return fmt.Errorf("%w: %s", ErrNotStruct, t)
+}
+
+// checkStruct returns an error if t is not a struct type.
+func checkStruct(t reflect.Type) error {
+	if t.Kind() != reflect.Struct {
+		return notStructError(t)
+	}
+	return nil
+}
+
+// withFieldPath prefixes the field path of a TagError with the name of the field containing it.
+func withFieldPath(name string, err error) error {
+	var tagErr *TagError
+	if errors.As(err, &tagErr) {
+		tagErr.Field = name + "." + tagErr.Field
+	}
+	return err
+}
+
+// fieldPath returns the dotted path of the field at index in struct type t. Exploded slices are marked by [].
+func fieldPath(t reflect.Type, index []int) string {
+	names := []string{}
+	for _, x := range index {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if x == explodeIndex {
+			names[len(names)-1] += "[]"
+			t = t.Elem()
+			continue
+		}
+		field := t.Field(x)
+		names = append(names, field.Name)
+		t = field.Type
+	}
+	return strings.Join(names, ".")
+}
+
+// ParseError is returned when a cell cannot be parsed into the field of its column.
+type ParseError struct {
+	// Row is the number of the row, starting from 1 for the first row after the header.
+	Row int
+	// Column is the label of the column.
+	Column string
+	// Value is the text of the cell.
+	Value string
+	// Err is the error parsing the cell.
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("Cannot parse %q in row %d, column %s: %v", e.Value, e.Row, e.Column, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
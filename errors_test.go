@@ -0,0 +1,93 @@
+package colprint
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+)
+
+type dummyUnexported struct {
+	Name   string `colprint:"Name,1"`
+	secret string `colprint:"Secret,2"`
+}
+
+type dummyNode struct {
+	Name string     `colprint:"Name"`
+	Next *dummyNode `colprint:"=>"`
+}
+
+func (s *UnitTests) TestFprint_InvalidInput() {
+	buf := new(bytes.Buffer)
+	s.True(errors.Is(Fprint(buf, 42), ErrNotStruct))
+	s.True(errors.Is(Fprint(buf, []string{"a", "b"}), ErrNotStruct))
+	s.True(errors.Is(Fprint(buf, []interface{}{DummyData{}, "a"}), ErrNotStruct))
+	s.True(errors.Is(Fprint(buf, nil), ErrNilValue))
+	s.True(errors.Is(Fprint(buf, (*DummyData)(nil)), ErrNilValue))
+	s.Empty(buf.String())
+}
+
+func (s *UnitTests) TestFprint_TagError() {
+	type A struct {
+		Errornous `colprint:"=>"`
+	}
+	type B struct {
+		Inner *A `colprint:"=>"`
+	}
+
+	err := Fprint(new(bytes.Buffer), B{})
+	var tagErr *TagError
+	s.True(errors.As(err, &tagErr))
+	s.Equal("Inner.Errornous.Error", tagErr.Field)
+	s.Equal("Error,a", tagErr.Tag)
+	s.Equal(`Invalid tag "Error,a" on field Inner.Errornous.Error: invalid order`, err.Error())
+
+	type C struct {
+		Name string `colprint:"Name,1,2"`
+	}
+	s.True(errors.As(Fprint(new(bytes.Buffer), C{}), &tagErr))
+	s.Equal("Name", tagErr.Field)
+}
+
+func (s *UnitTests) TestFprint_RecursiveTraversal() {
+	list := []dummyNode{{Name: "a", Next: &dummyNode{Name: "b", Next: &dummyNode{Name: "c"}}}, {Name: "x"}}
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, list))
+	s.Equal("Name  Name  Name\na     b     c\nx           ", buf.String())
+
+	buf.Reset()
+	s.NoError(Fprint(buf, dummyNode{Name: "a"}))
+	s.Equal("Name\na", buf.String())
+
+	cycle := &dummyNode{Name: "a", Next: &dummyNode{Name: "b"}}
+	cycle.Next.Next = cycle
+	var tagErr *TagError
+	s.True(errors.As(Fprint(new(bytes.Buffer), cycle), &tagErr))
+	s.Equal("Next.Next.Next", tagErr.Field)
+	s.Equal("cyclic traversal of colprint.dummyNode", tagErr.Reason)
+}
+
+func (s *UnitTests) TestFprint_FieldAccessError() {
+	err := Fprint(new(bytes.Buffer), dummyUnexported{Name: "name"})
+	var accessErr *FieldAccessError
+	s.True(errors.As(err, &accessErr))
+	s.Equal("secret", accessErr.Field)
+}
+
+func (s *UnitTests) TestSprint_NilTraversal() {
+	type A struct {
+		Name string `colprint:"Name,1"`
+	}
+	type B struct {
+		*A   `colprint:"=>"`
+		Date string `colprint:"Date,2"`
+	}
+	val, err := Sprint([]*B{{Date: "today"}, nil, {A: &A{Name: "Ola"}, Date: "tomorrow"}})
+	s.NoError(err)
+	s.Equal("Name  Date\n      today\n      \nOla   tomorrow", val)
+}
+
+func (s *UnitTests) TestFieldPath() {
+	t := reflect.TypeOf(Person{})
+	s.Equal("Data.Age", fieldPath(t, []int{6, 3}))
+	s.Equal("FirstName", fieldPath(t, []int{0}))
+}
@@ -0,0 +1,122 @@
+package colprint
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// explodeIndex is the field index marking an exploded slice in the field index of a column. The element of the
+// slice is chosen per row.
+const explodeIndex = -1
+
+// pathKey returns the key of an exploded slice, identified by the field index leading to it.
+func pathKey(path []int) string {
+	return fmt.Sprint(path)
+}
+
+// walk follows the field index path from struct v, taking elements of exploded slices from choices. Returns an
+// invalid value if a nil pointer or a missing slice element is met on the way.
+func walk(v reflect.Value, path []int, choices map[string]int) reflect.Value {
+	for i, x := range path {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+		}
+		if x == explodeIndex {
+			c, ok := choices[pathKey(path[:i])]
+			if !ok || c < 0 || c >= v.Len() {
+				return reflect.Value{}
+			}
+			v = v.Index(c)
+		} else {
+			v = v.Field(x)
+		}
+	}
+	return v
+}
+
+// explodeDepth returns the number of exploded slices the column is nested in.
+func (c column) explodeDepth() int {
+	depth := 0
+	for _, x := range *c.fieldIndex {
+		if x == explodeIndex {
+			depth++
+		}
+	}
+	return depth
+}
+
+// sameChoices reports whether the column has the same value in two rows exploded from the same item, i.e.
+// whether the same elements are chosen from all the slices the column is nested in.
+func (c column) sameChoices(a, b map[string]int) bool {
+	path := *c.fieldIndex
+	for i, x := range path {
+		if x == explodeIndex && a[pathKey(path[:i])] != b[pathKey(path[:i])] {
+			return false
+		}
+	}
+	return true
+}
+
+// explodes returns the paths to the slices exploded by the columns, outermost first.
+func (s columns) explodes() [][]int {
+	seen := make(map[string]bool)
+	paths := [][]int{}
+	for depth := 1; ; depth++ {
+		found := false
+		for _, col := range s {
+			if col.fieldIndex == nil || col.explodeDepth() < depth {
+				continue
+			}
+			found = true
+			path := *col.fieldIndex
+			n := 0
+			for i, x := range path {
+				if x == explodeIndex {
+					n++
+				}
+				if n == depth {
+					if key := pathKey(path[:i]); !seen[key] {
+						seen[key] = true
+						paths = append(paths, path[:i])
+					}
+					break
+				}
+			}
+		}
+		if !found {
+			return paths
+		}
+	}
+}
+
+// explodeChoices returns the rows of struct v as choices of elements of the exploded slices, one row for each
+// combination of elements. Like an outer join, an empty slice gives a single row where the element is missing.
+func (cp *cPrinter) explodeChoices(v reflect.Value) []map[string]int {
+	choices := []map[string]int{{}}
+	for _, path := range cp.explodes {
+		key := pathKey(path)
+		next := []map[string]int{}
+		for _, choice := range choices {
+			n := 0
+			if slice := walk(v, path, choice); slice.IsValid() {
+				n = slice.Len()
+			}
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				c := make(map[string]int, len(choice)+1)
+				for k, x := range choice {
+					c[k] = x
+				}
+				c[key] = i
+				next = append(next, c)
+			}
+		}
+		choices = next
+	}
+	return choices
+}
@@ -0,0 +1,101 @@
+package colprint
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+)
+
+type dummyContainer struct {
+	Image string `colprint:"Image,1"`
+	Ports []int  `colprint:"Ports,2"`
+}
+
+type dummyPod struct {
+	Name       string            `colprint:"Name,2"`
+	Namespace  string            `colprint:"Namespace,1"`
+	Containers []*dummyContainer `colprint:"=>,explode"`
+}
+
+type dummyDeployment struct {
+	Name string     `colprint:"Deployment,1"`
+	Pods []dummyPod `colprint:"=>,explode"`
+}
+
+func (s *UnitTests) TestSprint_Explode() {
+	pods := []dummyPod{
+		{Name: "web", Namespace: "prod", Containers: []*dummyContainer{{Image: "nginx", Ports: []int{80, 443}}, {Image: "envoy"}}},
+		{Name: "db", Namespace: "prod"},
+		{Name: "cache", Namespace: "dev", Containers: []*dummyContainer{nil, {Image: "redis"}}},
+	}
+	val, err := Sprint(pods)
+	s.NoError(err)
+	s.Equal("Namespace  Name   Image  Ports\n"+
+		"prod       web    nginx  80, 443\n"+
+		"prod       web    envoy  \n"+
+		"prod       db            \n"+
+		"dev        cache         \n"+
+		"dev        cache  redis  ", val)
+}
+
+func (s *UnitTests) TestFprint_ExplodeBlankRepeatedValues() {
+	deployment := dummyDeployment{
+		Name: "frontend",
+		Pods: []dummyPod{
+			{Name: "web-1", Namespace: "prod", Containers: []*dummyContainer{{Image: "nginx"}, {Image: "envoy"}}},
+			{Name: "web-2", Namespace: "prod", Containers: []*dummyContainer{{Image: "nginx"}}},
+		},
+	}
+	blank := true
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, deployment, &Config{BlankRepeatedValues: &blank}))
+	s.Equal("Deployment  Namespace  Name   Image  Ports\n"+
+		"frontend    prod       web-1  nginx  \n"+
+		"                              envoy  \n"+
+		"            prod       web-2  nginx  ", buf.String())
+}
+
+func (s *UnitTests) TestFprint_ExplodeWithColumns() {
+	pods := []dummyPod{{Name: "web", Containers: []*dummyContainer{{Image: "nginx"}, {Image: "envoy"}}}}
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, pods, &Config{Columns: []string{"Name"}}))
+	s.Equal("Name\nweb", buf.String())
+}
+
+func (s *UnitTests) TestFprint_ExplodeTagErrors() {
+	type A struct {
+		Name string `colprint:"=>,explode"`
+	}
+	type B struct {
+		Names []string `colprint:"=>,explode"`
+	}
+	type C struct {
+		Pods []dummyPod `colprint:"=>,unknown"`
+	}
+	var tagErr *TagError
+	s.True(errors.As(Fprint(new(bytes.Buffer), A{}), &tagErr))
+	s.True(errors.As(Fprint(new(bytes.Buffer), B{}), &tagErr))
+	s.True(errors.As(Fprint(new(bytes.Buffer), C{}), &tagErr))
+	s.Equal("Pods", tagErr.Field)
+}
+
+func (s *UnitTests) TestColumns_Explodes() {
+	cp := cPrinter{config: createDefaultConfig()}
+	s.NoError(cp.initColumns(reflect.TypeOf(dummyDeployment{})))
+	s.Equal([][]int{{1}, {1, explodeIndex, 2}}, cp.explodes)
+	s.Equal(0, cp.cols[0].explodeDepth())
+	s.Equal(2, cp.cols[len(cp.cols)-1].explodeDepth())
+}
+
+func (s *UnitTests) TestParseTraverseTag() {
+	opts, err := parseTraverseTag("=>")
+	s.NoError(err)
+	s.False(opts.explode)
+
+	opts, err = parseTraverseTag("=>,explode")
+	s.NoError(err)
+	s.True(opts.explode)
+
+	_, err = parseTraverseTag("=>,other")
+	s.Error(err)
+}
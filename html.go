@@ -0,0 +1,171 @@
+package colprint
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"html/template"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// htmlTemplates renders tables and standalone documents. Values are escaped by html/template.
+var htmlTemplates = template.Must(template.New("document").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+{{range .Tables}}{{template "table" .}}
+{{end}}</body>
+</html>
+{{define "table"}}<table>
+<thead>
+<tr>{{range .Headers}}<th{{with .Class}} class="{{.}}"{{end}}>{{.Value}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{range .Rows}}<tr{{with .Class}} class="{{.}}"{{end}}>{{range .Cells}}<td{{with .Class}} class="{{.}}"{{end}}{{if .Sorted}} data-sort="{{.Sort}}"{{end}}>{{.Value}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>{{end}}`))
+
+// htmlDocument is the data of a standalone HTML document.
+type htmlDocument struct {
+	Title  string
+	Tables []htmlTable
+}
+
+// htmlTable is the data of an HTML table.
+type htmlTable struct {
+	Headers []htmlCell
+	Rows    []htmlRow
+}
+
+// htmlRow is the data of a row of an HTML table.
+type htmlRow struct {
+	Class string
+	Cells []htmlCell
+}
+
+// htmlCell is the data of a cell of an HTML table.
+type htmlCell struct {
+	Value  string
+	Class  string
+	Sort   string
+	Sorted bool
+}
+
+// FprintHTML prints struct or slice to provided io.Writer as an HTML table using provided config. The cells of
+// columns tagged with the class option get that class, and rows get the class returned by Config.HTMLRowClass.
+// Set Config.HTMLSortAttributes to add the raw values of the cells as data-sort attributes, and
+// Config.HTMLDocument to print a standalone HTML document.
+// If config is nil, default config will be used.
+func FprintHTML(w io.Writer, s interface{}, c ...*Config) error {
+	return fprintRendered(w, s, htmlRenderer{}, c...)
+}
+
+// htmlRenderer renders tables as HTML tables, or as a standalone HTML document if Config.HTMLDocument is set.
+type htmlRenderer struct{}
+
+func (htmlRenderer) ContentType() string {
+	return "text/html; charset=utf-8"
+}
+
+func (r htmlRenderer) Render(w io.Writer, t *TableModel) error {
+	return r.RenderTables(w, []*TableModel{t})
+}
+
+func (htmlRenderer) RenderTables(w io.Writer, tables []*TableModel) error {
+	if len(tables) == 0 {
+		return nil
+	}
+	config := tables[0].Config
+	doc := htmlDocument{Title: *config.HTMLTitle}
+	for _, t := range tables {
+		doc.Tables = append(doc.Tables, newHTMLTable(t))
+	}
+
+	var buf bytes.Buffer
+	var err error
+	if *config.HTMLDocument {
+		err = htmlTemplates.Execute(&buf, doc)
+	} else {
+		for i, table := range doc.Tables {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			if err = htmlTemplates.ExecuteTemplate(&buf, "table", table); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return writeString(w, buf.String())
+}
+
+// newHTMLTable returns the data of the HTML table of t.
+func newHTMLTable(t *TableModel) htmlTable {
+	table := htmlTable{}
+	for _, col := range t.Columns {
+		table.Headers = append(table.Headers, htmlCell{Value: col.Label, Class: col.Class})
+	}
+	for _, modelRow := range t.Rows {
+		row := htmlRow{}
+		if t.Config.HTMLRowClass != nil {
+			row.Class = t.Config.HTMLRowClass(modelRow.Item)
+		}
+		for j, col := range t.Columns {
+			cell := htmlCell{Value: modelRow.Values[j], Class: col.Class}
+			if *t.Config.HTMLSortAttributes {
+				cell.Sort = rawText(modelRow.Raw[j], cell.Value)
+				cell.Sorted = true
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// rawText returns a raw value as text, without the formatting of valueOf: numbers are printed in full precision
+// and times in RFC 3339 format. Other values are returned as formatted.
+func rawText(raw interface{}, formatted string) string {
+	if valuer, ok := raw.(driver.Valuer); ok && !isNilPointer(raw) {
+		value, err := valuer.Value()
+		if err != nil {
+			return formatted
+		}
+		raw = value
+	}
+	v := reflect.ValueOf(raw)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Struct:
+		if t, ok := v.Interface().(time.Time); ok {
+			return t.Format(time.RFC3339Nano)
+		}
+	}
+	return formatted
+}
+
+// isNilPointer reports whether v is a nil pointer, e.g. a nil *sql.NullString whose Value method would panic.
+func isNilPointer(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
@@ -0,0 +1,87 @@
+package colprint
+
+import (
+	"bytes"
+	"database/sql"
+	"time"
+)
+
+type dummyHost struct {
+	Name    string    `colprint:"Name,1,class=name"`
+	Load    float64   `colprint:"Load,2"`
+	Started time.Time `colprint:"-"`
+}
+
+func (s *UnitTests) TestFprintHTML() {
+	hosts := []dummyHost{{Name: "<web>", Load: 0.375}, {Name: "db & co", Load: 2}}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintHTML(buf, hosts))
+	s.Equal(`<table>
+<thead>
+<tr><th class="name">Name</th><th>Load</th></tr>
+</thead>
+<tbody>
+<tr><td class="name">&lt;web&gt;</td><td>0.38</td></tr>
+<tr><td class="name">db &amp; co</td><td>2.00</td></tr>
+</tbody>
+</table>`, buf.String())
+}
+
+func (s *UnitTests) TestFprintHTML_Options() {
+	hosts := []dummyHost{{Name: "web", Load: 0.375}}
+	sa := true
+	doc := true
+	title := "Hosts <1>"
+	buf := new(bytes.Buffer)
+	s.NoError(FprintHTML(buf, hosts, &Config{
+		HTMLSortAttributes: &sa,
+		HTMLDocument:       &doc,
+		HTMLTitle:          &title,
+		HTMLRowClass: func(item interface{}) string {
+			if item.(dummyHost).Load < 1 {
+				return `idle "host"`
+			}
+			return ""
+		},
+	}))
+	s.Equal(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Hosts &lt;1&gt;</title>
+</head>
+<body>
+<table>
+<thead>
+<tr><th class="name">Name</th><th>Load</th></tr>
+</thead>
+<tbody>
+<tr class="idle &#34;host&#34;"><td class="name" data-sort="web">web</td><td data-sort="0.375">0.38</td></tr>
+</tbody>
+</table>
+</body>
+</html>
+`, buf.String())
+}
+
+func (s *UnitTests) TestFprintHTML_Error() {
+	s.Equal(ErrNilValue, FprintHTML(new(bytes.Buffer), nil))
+	type C struct {
+		Name string `colprint:"Name,1,color=red"`
+	}
+	s.EqualError(FprintHTML(new(bytes.Buffer), C{}), `Invalid tag "Name,1,color=red" on field Name: unknown option color=red`)
+}
+
+func (s *UnitTests) TestRawText() {
+	n := 42
+	var nilPtr *int
+	t := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	s.Equal("42", rawText(&n, ""))
+	s.Equal("", rawText(nilPtr, ""))
+	s.Equal("0.1", rawText(float32(0.1), "0.10"))
+	s.Equal("2020-01-02T03:04:05Z", rawText(t, ""))
+	s.Equal("a, b", rawText([]string{"a", "b"}, "a, b"))
+	var nilValuer *sql.NullInt64
+	s.Equal("", rawText(nilValuer, ""))
+	s.Equal("7", rawText(&sql.NullInt64{Int64: 7, Valid: true}, ""))
+}
@@ -0,0 +1,126 @@
+package colprint
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultContentType is the content type of the output of Renderers that do not implement ContentTyper.
+const defaultContentType = "text/plain; charset=utf-8"
+
+// Handler returns an http.Handler printing the value returned by provider using provided config. The format is the
+// name of a registered Renderer, chosen by the format query parameter or else by the Accept header among the
+// renderers implementing ContentTyper, and defaults to text. Renderers that do not implement ContentTyper are served
+// as plain text. The query parameters columns, sort and filter set Config.Columns, Config.SortBy and
+// Config.Filters, e.g. ?columns=Name,Age&sort=-Age&filter=Age>=18. The filter parameter can be repeated.
+// Invalid parameters are served as 400 Bad Request, and errors of the provider, or values it returns that cannot
+// be printed, as 500 Internal Server Error.
+// If config is nil, default config will be used.
+func Handler(provider func(r *http.Request) (interface{}, error), c ...*Config) http.Handler {
+	var conf Config
+	if len(c) > 0 && c[0] != nil {
+		conf = *c[0]
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("format")
+		if name == "" {
+			name = negotiateFormat(r.Header.Get("Accept"))
+		}
+		renderer, ok := LookupRenderer(name)
+		if !ok {
+			http.Error(w, "Unknown format "+name, http.StatusBadRequest)
+			return
+		}
+		contentType := defaultContentType
+		if ct, ok := renderer.(ContentTyper); ok {
+			contentType = ct.ContentType()
+		}
+
+		reqConf := conf
+		reqConf.Format = &name
+		baseConf := reqConf
+		query := r.URL.Query()
+		if columns := query.Get("columns"); columns != "" {
+			reqConf.Columns = strings.Split(columns, ",")
+		}
+		if sortBy := query.Get("sort"); sortBy != "" {
+			reqConf.SortBy = strings.Split(sortBy, ",")
+		}
+		if filters := query["filter"]; len(filters) > 0 {
+			reqConf.Filters = append(append([]string{}, conf.Filters...), filters...)
+		}
+
+		s, err := provider(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		var buf bytes.Buffer
+		if err := Fprint(&buf, s, &reqConf); err != nil {
+			// errors that remain without the query parameters are errors of the provided value
+			status := http.StatusBadRequest
+			if Fprint(io.Discard, s, &baseConf) != nil {
+				status = http.StatusInternalServerError
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Vary", "Accept")
+		buf.WriteTo(w)
+	})
+}
+
+// negotiateFormat returns the name of the renderer whose content type best matches an Accept header, or text if
+// none match.
+func negotiateFormat(accept string) string {
+	type candidate struct {
+		name    string
+		quality float64
+	}
+	types := rendererMediaTypes()
+	candidates := []candidate{}
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil {
+			quality = q
+		}
+		if name, ok := types[mediaType]; ok && quality > 0 {
+			candidates = append(candidates, candidate{name, quality})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	if len(candidates) == 0 {
+		return "text"
+	}
+	return candidates[0].name
+}
+
+// rendererMediaTypes returns the names of the registered renderers implementing ContentTyper by the media type of
+// their content type. Of renderers of the same media type, the first by name is returned.
+func rendererMediaTypes() map[string]string {
+	types := map[string]string{}
+	for _, name := range RendererNames() {
+		r, _ := LookupRenderer(name)
+		ct, ok := r.(ContentTyper)
+		if !ok {
+			continue
+		}
+		mediaType, _, err := mime.ParseMediaType(ct.ContentType())
+		if _, found := types[mediaType]; err == nil && !found {
+			types[mediaType] = name
+		}
+	}
+	return types
+}
@@ -0,0 +1,100 @@
+package colprint
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+)
+
+func hostsHandler() http.Handler {
+	return Handler(func(r *http.Request) (interface{}, error) {
+		return []dummyHost{{Name: "web", Load: 0.375}, {Name: "db", Load: 2}, {Name: "cache", Load: 1}}, nil
+	})
+}
+
+func serve(h http.Handler, target, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", target, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *UnitTests) TestHandler_Text() {
+	rec := serve(hostsHandler(), "/hosts?sort=-Load&filter=Load>0.5&filter=Name!=cache", "")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	s.Equal("Name  Load\ndb    2.00", rec.Body.String())
+
+	rec = serve(hostsHandler(), "/hosts?format=org&filter=Name=db", "")
+	s.Equal("text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	s.Equal("| Name | Load |\n|------+------|\n| db   | 2.00 |", rec.Body.String())
+}
+
+func (s *UnitTests) TestHandler_Negotiation() {
+	rec := serve(hostsHandler(), "/hosts?columns=Name", "text/html;q=0.5, application/json")
+	s.Equal("application/json", rec.Header().Get("Content-Type"))
+	s.Equal("[\n  {\"Name\": \"web\"},\n  {\"Name\": \"db\"},\n  {\"Name\": \"cache\"}\n]", rec.Body.String())
+
+	rec = serve(hostsHandler(), "/hosts", "text/html")
+	s.Equal("text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	s.Contains(rec.Body.String(), "<table>")
+
+	rec = serve(hostsHandler(), "/hosts?format=csv&columns=Name", "application/json")
+	s.Equal("text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	s.Equal("Name\nweb\ndb\ncache\n", rec.Body.String())
+
+	rec = serve(hostsHandler(), "/hosts?columns=Name", "*/*")
+	s.Equal("text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+
+	table := "hosts"
+	h := Handler(func(r *http.Request) (interface{}, error) {
+		return []dummyHost{{Name: "web"}}, nil
+	}, &Config{SQLTable: &table, Columns: []string{"Name"}})
+	rec = serve(h, "/hosts", "application/sql")
+	s.Equal("application/sql", rec.Header().Get("Content-Type"))
+	s.Equal(`INSERT INTO "hosts" ("Name") VALUES ('web');`, rec.Body.String())
+
+	rec = serve(h, "/hosts?format=copy", "")
+	s.Equal("text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	s.Equal("COPY \"hosts\" (\"Name\") FROM stdin;\nweb\n\\.", rec.Body.String())
+}
+
+func (s *UnitTests) TestHandler_Errors() {
+	s.Equal(http.StatusBadRequest, serve(hostsHandler(), "/hosts?format=xml", "").Code)
+	s.Equal(http.StatusBadRequest, serve(hostsHandler(), "/hosts?columns=Unknown", "").Code)
+	s.Equal(http.StatusBadRequest, serve(hostsHandler(), "/hosts?filter=Load", "").Code)
+
+	h := Handler(func(r *http.Request) (interface{}, error) {
+		return nil, errors.New("unavailable")
+	})
+	rec := serve(h, "/hosts", "")
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Equal("unavailable\n", rec.Body.String())
+
+	h = Handler(func(r *http.Request) (interface{}, error) {
+		return []int{1, 2}, nil
+	})
+	s.Equal(http.StatusInternalServerError, serve(h, "/ints", "").Code)
+	s.Equal(http.StatusInternalServerError, serve(h, "/ints?sort=Name", "").Code)
+
+	type badTag struct {
+		Name string `colprint:"Name,x"`
+	}
+	h = Handler(func(r *http.Request) (interface{}, error) {
+		return []badTag{{"web"}}, nil
+	})
+	s.Equal(http.StatusInternalServerError, serve(h, "/bad?columns=Name", "").Code)
+}
+
+func (s *UnitTests) TestNegotiateFormat() {
+	s.Equal("text", negotiateFormat(""))
+	s.Equal("csv", negotiateFormat("text/csv"))
+	s.Equal("yaml", negotiateFormat("application/yaml"))
+	s.Equal("xlsx", negotiateFormat("application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"))
+	s.Equal("html", negotiateFormat("application/json;q=0.1, text/html;q=0.9"))
+	s.Equal("text", negotiateFormat("application/xml, image/png"))
+	s.Equal("text", negotiateFormat("application/json;q=0"))
+}
@@ -0,0 +1,118 @@
+package colprint
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+)
+
+// FprintJSON prints struct or slice to provided io.Writer as a JSON array of objects using provided config. The
+// objects are keyed by the column labels, in column order. Numbers, booleans and slices keep their types, nil
+// values are null, and other values are printed as formatted strings. Items of different struct types printed in
+// separate tables are printed in the same array.
+// If config is nil, default config will be used.
+func FprintJSON(w io.Writer, s interface{}, c ...*Config) error {
+	return fprintRendered(w, s, jsonRenderer{}, c...)
+}
+
+// jsonRenderer renders tables as a JSON array of objects, holding the rows of all the tables.
+type jsonRenderer struct{}
+
+func (jsonRenderer) ContentType() string {
+	return "application/json"
+}
+
+func (r jsonRenderer) Render(w io.Writer, t *TableModel) error {
+	return r.RenderTables(w, []*TableModel{t})
+}
+
+func (jsonRenderer) RenderTables(w io.Writer, tables []*TableModel) error {
+	var buf bytes.Buffer
+	buf.WriteString("[")
+	rows := 0
+	for _, t := range tables {
+		cp := modelPrinter(t)
+		for _, row := range t.Rows {
+			if rows > 0 {
+				buf.WriteString(",")
+			}
+			rows++
+			buf.WriteString("\n  {")
+			for j, col := range t.Columns {
+				if j > 0 {
+					buf.WriteString(", ")
+				}
+				key, err := json.Marshal(col.Label)
+				if err != nil {
+					return err
+				}
+				val, err := json.Marshal(cp.typedValue(row.Raw[j], row.Values[j]))
+				if err != nil {
+					return err
+				}
+				buf.Write(key)
+				buf.WriteString(": ")
+				buf.Write(val)
+			}
+			buf.WriteString("}")
+		}
+	}
+	if rows > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]")
+	return writeString(w, buf.String())
+}
+
+// typedValue returns a raw value printed as formatted as a typed value for JSON and YAML: nil, an int64, uint64 or
+// json.Number for numbers, a bool, a slice of typed values, or else a string.
+func (cp *cPrinter) typedValue(raw interface{}, formatted string) interface{} {
+	if valuer, ok := raw.(driver.Valuer); ok && !isNilPointer(raw) {
+		value, err := valuer.Value()
+		if err != nil {
+			return formatted
+		}
+		raw = value
+	}
+	if n, ok := raw.(json.Number); ok {
+		return n
+	}
+	v := reflect.ValueOf(raw)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		if math.IsNaN(v.Float()) || math.IsInf(v.Float(), 0) {
+			return formatted
+		}
+		return json.Number(rawText(v.Interface(), formatted))
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return formatted
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			item := v.Index(i).Interface()
+			items[i] = cp.typedValue(item, cp.valueOf(item))
+		}
+		return items
+	case reflect.Struct:
+		return rawText(v.Interface(), formatted)
+	}
+	return formatted
+}
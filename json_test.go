@@ -0,0 +1,54 @@
+package colprint
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"math"
+	"time"
+)
+
+type dummyMetric struct {
+	Name    string    `colprint:"Name,1"`
+	Value   float64   `colprint:"Value,2"`
+	Count   *int      `colprint:"Count,3"`
+	Up      bool      `colprint:"Up,4"`
+	Tags    []string  `colprint:"Tags,5"`
+	Updated time.Time `colprint:"Updated,6"`
+}
+
+func (s *UnitTests) TestFprintJSON() {
+	count := 3
+	metrics := []dummyMetric{
+		{Name: "cpu", Value: 0.375, Count: &count, Up: true, Tags: []string{"a", "b"},
+			Updated: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{Name: "mem", Value: math.NaN()},
+	}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintJSON(buf, metrics))
+	s.Equal(`[
+  {"Name": "cpu", "Value": 0.375, "Count": 3, "Up": true, "Tags": ["a","b"], "Updated": "2020-01-02T03:04:05Z"},
+  {"Name": "mem", "Value": "NaN", "Count": null, "Up": false, "Tags": [], "Updated": "0001-01-01T00:00:00Z"}
+]`, buf.String())
+	s.True(json.Valid(buf.Bytes()))
+
+	buf.Reset()
+	s.NoError(FprintJSON(buf, metrics[:1]))
+	parsed := []dummyMetric{}
+	s.NoError(UnmarshalJSON(buf.Bytes(), &parsed))
+	s.Equal(metrics[:1], parsed)
+
+	buf.Reset()
+	s.NoError(FprintJSON(buf, []dummyMetric{}))
+	s.Equal("[]", buf.String())
+}
+
+func (s *UnitTests) TestFprintJSON_NilValuer() {
+	type note struct {
+		Text *sql.NullString `colprint:"Text,1"`
+		Size *sql.NullInt64  `colprint:"Size,2"`
+	}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintJSON(buf, []note{{Size: &sql.NullInt64{Int64: 7, Valid: true}}}))
+	s.Equal("[\n  {\"Text\": null, \"Size\": 7}\n]", buf.String())
+}
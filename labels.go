@@ -0,0 +1,64 @@
+package colprint
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DuplicateLabels represents how columns with the same label are handled.
+type DuplicateLabels int
+
+const (
+	// DuplicateLabelsAllow prints columns with the same label.
+	DuplicateLabelsAllow DuplicateLabels = iota
+	// DuplicateLabelsWarn prints columns with the same label, and reports a DuplicateLabelError to Config.Warn.
+	DuplicateLabelsWarn
+	// DuplicateLabelsError returns a DuplicateLabelError when columns have the same label.
+	DuplicateLabelsError
+)
+
+// DuplicateLabelError is returned or reported as a warning when columns have the same label.
+type DuplicateLabelError struct {
+	// Label is the duplicated label.
+	Label string
+	// Fields are the dotted paths of the fields having the label, starting from the printed struct.
+	Fields []string
+}
+
+func (e *DuplicateLabelError) Error() string {
+	return fmt.Sprintf("Duplicate label %q on fields %s", e.Label, strings.Join(e.Fields, ", "))
+}
+
+// checkLabels reports columns of struct type t with the same label, as configured by Config.DuplicateLabels.
+func (cp *cPrinter) checkLabels(t reflect.Type) error {
+	if *cp.config.DuplicateLabels == DuplicateLabelsAllow {
+		return nil
+	}
+	fields := make(map[string][]string)
+	labels := []string{}
+	for _, col := range cp.cols {
+		if _, ok := fields[col.label]; !ok {
+			labels = append(labels, col.label)
+		}
+		fields[col.label] = append(fields[col.label], col.path(t))
+	}
+	for _, label := range labels {
+		if len(fields[label]) < 2 {
+			continue
+		}
+		err := &DuplicateLabelError{Label: label, Fields: fields[label]}
+		if *cp.config.DuplicateLabels == DuplicateLabelsError {
+			return err
+		}
+		cp.warn(err)
+	}
+	return nil
+}
+
+// warn reports a warning to Config.Warn, if set.
+func (cp *cPrinter) warn(err error) {
+	if cp.config.Warn != nil {
+		cp.config.Warn(err)
+	}
+}
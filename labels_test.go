@@ -0,0 +1,66 @@
+package colprint
+
+import (
+	"bytes"
+	"errors"
+)
+
+type dummyOwner struct {
+	Name  string `colprint:"Name,1"`
+	Email string `colprint:"Email,2"`
+}
+
+type dummyRepo struct {
+	Name  string     `colprint:"Name,1"`
+	Owner dummyOwner `colprint:"=>,prefix=Owner "`
+}
+
+type dummyProject struct {
+	Name  string      `colprint:"Name,1"`
+	Owner dummyOwner  `colprint:"=>"`
+	Repos []dummyRepo `colprint:"=>,explode"`
+}
+
+func (s *UnitTests) TestSprint_LabelPrefix() {
+	val, err := Sprint(dummyRepo{Name: "colprint", Owner: dummyOwner{Name: "Ola", Email: "ola@example.com"}})
+	s.NoError(err)
+	s.Equal("Name      Owner Name  Owner Email\ncolprint  Ola         ola@example.com", val)
+}
+
+func (s *UnitTests) TestFprint_PathLabels() {
+	project := dummyProject{
+		Name:  "tools",
+		Owner: dummyOwner{Name: "Kari"},
+		Repos: []dummyRepo{{Name: "colprint", Owner: dummyOwner{Name: "Ola"}}},
+	}
+	pathLabels := true
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, project, &Config{PathLabels: &pathLabels}))
+	s.Equal("Name   Owner.Name  Owner.Email  Repos.Name  Repos.Owner Name  Repos.Owner Email\n"+
+		"tools  Kari                     colprint    Ola               ", buf.String())
+}
+
+func (s *UnitTests) TestFprint_DuplicateLabels() {
+	project := dummyProject{Name: "tools"}
+
+	dl := DuplicateLabelsError
+	err := Fprint(new(bytes.Buffer), project, &Config{DuplicateLabels: &dl})
+	var dupErr *DuplicateLabelError
+	s.True(errors.As(err, &dupErr))
+	s.Equal("Name", dupErr.Label)
+	s.Equal([]string{"Name", "Owner.Name", "Repos[].Name"}, dupErr.Fields)
+
+	dl = DuplicateLabelsWarn
+	warnings := []error{}
+	warn := func(err error) {
+		warnings = append(warnings, err)
+	}
+	s.NoError(Fprint(new(bytes.Buffer), project, &Config{DuplicateLabels: &dl, Warn: warn}))
+	s.Len(warnings, 1)
+	s.NoError(Fprint(new(bytes.Buffer), project, &Config{DuplicateLabels: &dl}))
+
+	pathLabels := true
+	warnings = []error{}
+	s.NoError(Fprint(new(bytes.Buffer), project, &Config{DuplicateLabels: &dl, Warn: warn, PathLabels: &pathLabels}))
+	s.Empty(warnings)
+}
@@ -0,0 +1,162 @@
+package colprint
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ANSI escape sequences used to redraw live tables.
+const (
+	cursorUpFmt      = "\x1b[%dA"
+	clearLine        = "\r\x1b[2K"
+	clearToEnd       = "\x1b[J"
+	highlightChanged = "\x1b[7m"
+)
+
+// LiveTable prints successive snapshots of a struct or slice of structs, e.g. for watch-style commands. On a
+// terminal each snapshot is redrawn in place of the previous one, rewriting only the lines that changed. Otherwise
+// the snapshots are appended, separated by blank lines. Column widths grow as needed, but never shrink, to keep
+// the table from jittering between snapshots.
+// Tables taller than the terminal cannot be redrawn in place, and Config.PageSize is ignored.
+type LiveTable struct {
+	mu     sync.Mutex
+	w      io.Writer
+	config *Config
+	// tty is true when redrawing in place
+	tty bool
+	// widths holds the widths of the columns of each table, by label
+	widths []map[string]int
+	// cells holds the cells of each row of each table in the previous snapshot, by label
+	cells [][]map[string]string
+	// lines holds the lines of the previous snapshot, nil before the first snapshot
+	lines []string
+	lw    *lineWriter
+}
+
+// NewLiveTable creates a LiveTable writing to provided io.Writer using provided config. Snapshots are redrawn in
+// place if the writer is a terminal.
+// If config is nil, default config will be used.
+func NewLiveTable(w io.Writer, c ...*Config) *LiveTable {
+	var conf *Config
+	if len(c) > 0 {
+		conf = c[0]
+	}
+	lt := &LiveTable{w: w, config: mergeConfig(createDefaultConfig(), conf), lw: newLineWriter(w)}
+	if f, ok := w.(*os.File); ok {
+		lt.tty = isTerminal(f)
+	}
+	return lt
+}
+
+// Update prints a new snapshot of a struct or slice of structs. Cells that changed since the previous snapshot
+// are highlighted if Config.HighlightChanges is set and the snapshot is redrawn in place.
+func (lt *LiveTable) Update(s interface{}) error {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	printers, err := load(s, lt.config)
+	if err != nil {
+		return err
+	}
+	highlight := lt.tty && *lt.config.HighlightChanges
+
+	lines := []string{}
+	cells := make([][]map[string]string, len(printers))
+	for i, cp := range printers {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		if i >= len(lt.widths) {
+			lt.widths = append(lt.widths, make(map[string]int))
+		}
+		widths := lt.growWidths(lt.widths[i], cp)
+		headers := make([]string, len(cp.cols))
+		for j, col := range cp.cols {
+			headers[j] = col.label
+		}
+		lines = append(lines, formatLine(headers, widths))
+
+		vals := make([]string, len(cp.cols))
+		for row := 0; row < cp.itemCount; row++ {
+			styles := make([]string, len(cp.cols))
+			rowCells := make(map[string]string)
+			for j, col := range cp.cols {
+				vals[j] = truncate(cp.values[col][row], *cp.config.MaxColumnWidth)
+				rowCells[col.label] = vals[j]
+				if highlight && lt.changed(i, row, col.label, vals[j]) {
+					styles[j] = highlightChanged
+				}
+			}
+			cells[i] = append(cells[i], rowCells)
+			lines = append(lines, formatStyledLine(vals, widths, styles))
+		}
+	}
+
+	if lt.tty {
+		err = lt.redraw(lines)
+	} else {
+		err = lt.appendLines(lines)
+	}
+	lt.cells = cells
+	lt.lines = lines
+	return err
+}
+
+// growWidths grows the widths of the columns of a table to fit the values of cp, and returns the widths of the
+// columns of cp.
+func (lt *LiveTable) growWidths(tableWidths map[string]int, cp *cPrinter) []int {
+	widths := cp.widths()
+	for i, col := range cp.cols {
+		if widths[i] < tableWidths[col.label] {
+			widths[i] = tableWidths[col.label]
+		}
+		tableWidths[col.label] = widths[i]
+	}
+	return widths
+}
+
+// changed reports whether a cell differs from the cell of the same row and column in the previous snapshot. Cells
+// of new rows or columns are not reported as changed, nor are cells of the first snapshot.
+func (lt *LiveTable) changed(table, row int, label, val string) bool {
+	if table >= len(lt.cells) || row >= len(lt.cells[table]) {
+		return false
+	}
+	prev, ok := lt.cells[table][row][label]
+	return ok && prev != val
+}
+
+// redraw moves the cursor to the start of the previous snapshot and rewrites the lines that changed, leaving the
+// cursor on the line below the snapshot.
+func (lt *LiveTable) redraw(lines []string) error {
+	var b strings.Builder
+	if len(lt.lines) > 0 {
+		b.WriteString(fmt.Sprintf(cursorUpFmt, len(lt.lines)))
+	}
+	for i, line := range lines {
+		if i >= len(lt.lines) || lt.lines[i] != line {
+			b.WriteString(clearLine + line)
+		}
+		b.WriteString("\n")
+	}
+	if len(lines) < len(lt.lines) {
+		b.WriteString(clearToEnd)
+	}
+	return writeString(lt.w, b.String())
+}
+
+// appendLines writes the lines of a snapshot after the previous snapshot, separated by a blank line.
+func (lt *LiveTable) appendLines(lines []string) error {
+	if lt.lines != nil {
+		if err := lt.lw.writeLine(""); err != nil {
+			return err
+		}
+	}
+	for _, line := range lines {
+		if err := lt.lw.writeLine(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package colprint
+
+import (
+	"bytes"
+)
+
+type dummyProcess struct {
+	Name  string `colprint:"Name,1"`
+	State string `colprint:"State,2"`
+}
+
+func (s *UnitTests) TestLiveTable_Append() {
+	buf := new(bytes.Buffer)
+	lt := NewLiveTable(buf)
+	s.False(lt.tty)
+	s.NoError(lt.Update([]dummyProcess{{"web", "starting"}}))
+	s.NoError(lt.Update([]dummyProcess{{"search", "up"}}))
+	s.Equal("Name  State\nweb   starting\n\nName    State\nsearch  up", buf.String())
+
+	// the widths grow, but do not shrink
+	buf.Reset()
+	s.NoError(lt.Update([]dummyProcess{{"db", "up"}}))
+	s.Equal("\n\nName    State\ndb      up", buf.String())
+}
+
+func (s *UnitTests) TestLiveTable_Redraw() {
+	buf := new(bytes.Buffer)
+	lt := NewLiveTable(buf)
+	lt.tty = true
+	s.NoError(lt.Update([]dummyProcess{{"web", "up"}, {"db", "starting"}}))
+	s.Equal("\r\x1b[2KName  State\n\r\x1b[2Kweb   up\n\r\x1b[2Kdb    starting\n", buf.String())
+
+	// only the changed line is rewritten
+	buf.Reset()
+	s.NoError(lt.Update([]dummyProcess{{"web", "up"}, {"db", "up"}}))
+	s.Equal("\x1b[3A\n\n\r\x1b[2Kdb    up\n", buf.String())
+
+	// a shorter snapshot clears the lines below it
+	buf.Reset()
+	s.NoError(lt.Update([]dummyProcess{{"web", "up"}}))
+	s.Equal("\x1b[3A\n\n\x1b[J", buf.String())
+}
+
+func (s *UnitTests) TestLiveTable_HighlightChanges() {
+	hc := true
+	buf := new(bytes.Buffer)
+	lt := NewLiveTable(buf, &Config{HighlightChanges: &hc})
+	lt.tty = true
+	s.NoError(lt.Update([]dummyProcess{{"web", "starting"}}))
+	buf.Reset()
+	s.NoError(lt.Update([]dummyProcess{{"web", "up"}, {"db", "up"}}))
+	s.Equal("\x1b[2A\n\r\x1b[2Kweb   \x1b[7mup\x1b[0m\n\r\x1b[2Kdb    up\n", buf.String())
+
+	// the highlight is removed when the cell no longer changes
+	buf.Reset()
+	s.NoError(lt.Update([]dummyProcess{{"web", "up"}, {"db", "up"}}))
+	s.Equal("\x1b[3A\n\r\x1b[2Kweb   up\n\n", buf.String())
+}
+
+func (s *UnitTests) TestLiveTable_Error() {
+	lt := NewLiveTable(new(bytes.Buffer))
+	s.Error(lt.Update(42))
+}
+
+func (s *UnitTests) TestFormatStyledLine() {
+	s.Equal("\x1b[7ma\x1b[0m   b", formatStyledLine([]string{"a", "b"}, []int{2, 1}, []string{"\x1b[7m", ""}))
+}
@@ -0,0 +1,253 @@
+package colprint
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// newlineReplacer joins the lines of multiline values, which cannot span lines in markup tables.
+var newlineReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// latexEscaper escapes the special characters of LaTeX.
+var latexEscaper = strings.NewReplacer(`\`, `\textbackslash{}`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`,
+	"_", `\_`, "{", `\{`, "}", `\}`, "~", `\textasciitilde{}`, "^", `\textasciicircum{}`)
+
+// asciiDocEscaper escapes the cell separator of AsciiDoc tables.
+var asciiDocEscaper = strings.NewReplacer("|", `\|`)
+
+// rstEscaper escapes the inline markup characters of reStructuredText.
+var rstEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "`", "\\`", "|", `\|`, "_", `\_`)
+
+// orgEscaper escapes the cell separator of Org-mode tables.
+var orgEscaper = strings.NewReplacer("|", `\vert{}`)
+
+// markupTable holds the escaped labels and values of a table, and the widths and alignment of its columns.
+type markupTable struct {
+	headers []string
+	rows    [][]string
+	widths  []int
+	// right holds whether each column is right aligned
+	right []bool
+}
+
+// FprintLaTeX prints struct or slice to provided io.Writer as a LaTeX tabular using provided config. Numeric
+// columns are right aligned, and special characters are escaped. Set Config.LaTeXBooktabs to draw the rules with
+// the booktabs package.
+// If config is nil, default config will be used.
+func FprintLaTeX(w io.Writer, s interface{}, c ...*Config) error {
+	return fprintRendered(w, s, latexRenderer{}, c...)
+}
+
+// FprintAsciiDoc prints struct or slice to provided io.Writer as an AsciiDoc table with a header row using
+// provided config. Numeric columns are right aligned.
+// If config is nil, default config will be used.
+func FprintAsciiDoc(w io.Writer, s interface{}, c ...*Config) error {
+	return fprintRendered(w, s, asciiDocRenderer{}, c...)
+}
+
+// FprintRST prints struct or slice to provided io.Writer as a reStructuredText grid table using provided config,
+// or as a simple table if Config.RSTSimple is set. Inline markup characters are escaped.
+// If config is nil, default config will be used.
+func FprintRST(w io.Writer, s interface{}, c ...*Config) error {
+	return fprintRendered(w, s, rstRenderer{}, c...)
+}
+
+// FprintOrg prints struct or slice to provided io.Writer as an Emacs Org-mode table using provided config.
+// If config is nil, default config will be used.
+func FprintOrg(w io.Writer, s interface{}, c ...*Config) error {
+	return fprintRendered(w, s, orgRenderer{}, c...)
+}
+
+// fprintRendered prints struct or slice to provided io.Writer using renderer r and provided config.
+func fprintRendered(w io.Writer, s interface{}, r Renderer, c ...*Config) error {
+	printers, err := load(s, c...)
+	if err != nil {
+		return err
+	}
+	return fprintAll(w, printers, r)
+}
+
+// latexRenderer renders tables as LaTeX tabulars.
+type latexRenderer struct{}
+
+func (latexRenderer) Render(w io.Writer, model *TableModel) error {
+	t := newMarkupTable(model, latexEscaper.Replace)
+	top, mid, bottom := `\hline`, `\hline`, `\hline`
+	if *model.Config.LaTeXBooktabs {
+		top, mid, bottom = `\toprule`, `\midrule`, `\bottomrule`
+	}
+	spec := ""
+	for _, right := range t.right {
+		if right {
+			spec += "r"
+		} else {
+			spec += "l"
+		}
+	}
+	lines := []string{`\begin{tabular}{` + spec + `}`, top, t.line(t.headers, "", " & ", ` \\`), mid}
+	for _, row := range t.rows {
+		lines = append(lines, t.line(row, "", " & ", ` \\`))
+	}
+	return writeLines(w, append(lines, bottom, `\end{tabular}`))
+}
+
+// asciiDocRenderer renders tables as AsciiDoc tables.
+type asciiDocRenderer struct{}
+
+func (asciiDocRenderer) Render(w io.Writer, model *TableModel) error {
+	t := newMarkupTable(model, asciiDocEscaper.Replace)
+	specs := make([]string, len(t.right))
+	for i, right := range t.right {
+		if right {
+			specs[i] = ">"
+		} else {
+			specs[i] = "<"
+		}
+	}
+	lines := []string{`[cols="` + strings.Join(specs, ",") + `",options="header"]`, "|==="}
+	for _, row := range append([][]string{t.headers}, t.rows...) {
+		lines = append(lines, strings.TrimRight(t.line(row, "| ", " | ", ""), " "))
+	}
+	return writeLines(w, append(lines, "|==="))
+}
+
+// rstRenderer renders tables as reStructuredText grid tables, or simple tables if Config.RSTSimple is set.
+type rstRenderer struct{}
+
+func (rstRenderer) Render(w io.Writer, model *TableModel) error {
+	t := newMarkupTable(model, rstEscaper.Replace)
+	if *model.Config.RSTSimple {
+		return writeLines(w, t.rstSimpleLines())
+	}
+	return writeLines(w, t.rstGridLines())
+}
+
+// rstGridLines returns the lines of the table as a reStructuredText grid table.
+func (t markupTable) rstGridLines() []string {
+	border := t.rule("+-", "-+-", "-+", "-")
+	lines := []string{border, t.line(t.headers, "| ", " | ", " |"), t.rule("+=", "=+=", "=+", "=")}
+	for _, row := range t.rows {
+		lines = append(lines, t.line(row, "| ", " | ", " |"), border)
+	}
+	if len(t.rows) == 0 {
+		lines = append(lines, border)
+	}
+	return lines
+}
+
+// rstSimpleLines returns the lines of the table as a reStructuredText simple table. Blank values of the first
+// column, which would continue the previous row, are escaped.
+func (t markupTable) rstSimpleLines() []string {
+	for i, row := range t.rows {
+		if len(row) > 0 && row[0] == "" {
+			t.rows[i][0] = `\`
+		}
+	}
+	t.growWidths(t.rows...)
+	for i := range t.widths {
+		if t.widths[i] == 0 {
+			t.widths[i] = 1
+		}
+	}
+	rule := t.rule("", "  ", "", "=")
+	lines := []string{rule, strings.TrimRight(t.line(t.headers, "", "  ", ""), " "), rule}
+	for _, row := range t.rows {
+		lines = append(lines, strings.TrimRight(t.line(row, "", "  ", ""), " "))
+	}
+	return append(lines, rule)
+}
+
+// orgRenderer renders tables as Org-mode tables.
+type orgRenderer struct{}
+
+func (orgRenderer) Render(w io.Writer, model *TableModel) error {
+	t := newMarkupTable(model, orgEscaper.Replace)
+	lines := []string{t.line(t.headers, "| ", " | ", " |"), t.rule("|-", "-+-", "-|", "-")}
+	for _, row := range t.rows {
+		lines = append(lines, t.line(row, "| ", " | ", " |"))
+	}
+	return writeLines(w, lines)
+}
+
+// newMarkupTable creates a markupTable of the labels and values of model, truncating the values to
+// Config.MaxColumnWidth, joining the lines of multiline values and escaping them with escape.
+func newMarkupTable(model *TableModel, escape func(string) string) markupTable {
+	t := markupTable{widths: make([]int, len(model.Columns)), right: make([]bool, len(model.Columns))}
+	for i, col := range model.Columns {
+		t.headers = append(t.headers, escape(newlineReplacer.Replace(col.Label)))
+		t.right[i] = numericColumn(model, i)
+	}
+	for _, modelRow := range model.Rows {
+		row := make([]string, len(modelRow.Values))
+		for j, val := range truncateValues(modelRow.Values, *model.Config.MaxColumnWidth) {
+			row[j] = escape(newlineReplacer.Replace(val))
+		}
+		t.rows = append(t.rows, row)
+	}
+	t.growWidths(append([][]string{t.headers}, t.rows...)...)
+	return t
+}
+
+// numericColumn reports whether column j of t holds numbers, i.e. whether all its values that are not nil are
+// numbers and it has any.
+func numericColumn(t *TableModel, j int) bool {
+	cp := modelPrinter(t)
+	numbers := 0
+	for _, row := range t.Rows {
+		switch cp.typedValue(row.Raw[j], row.Values[j]).(type) {
+		case nil:
+		case int64, uint64, json.Number:
+			numbers++
+		default:
+			return false
+		}
+	}
+	return numbers > 0
+}
+
+// growWidths grows the widths of the columns to fit the cells of rows.
+func (t markupTable) growWidths(rows ...[]string) {
+	for _, row := range rows {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > t.widths[i] {
+				t.widths[i] = n
+			}
+		}
+	}
+}
+
+// line returns the cells padded to the widths of their columns, separated by sep between prefix and suffix.
+func (t markupTable) line(cells []string, prefix, sep, suffix string) string {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		padding := strings.Repeat(" ", t.widths[i]-utf8.RuneCountInString(cell))
+		if t.right[i] {
+			padded[i] = padding + cell
+		} else {
+			padded[i] = cell + padding
+		}
+	}
+	return prefix + strings.Join(padded, sep) + suffix
+}
+
+// rule returns a horizontal rule of the table, drawn with fill under each column.
+func (t markupTable) rule(prefix, sep, suffix, fill string) string {
+	fills := make([]string, len(t.widths))
+	for i, width := range t.widths {
+		fills[i] = strings.Repeat(fill, width)
+	}
+	return prefix + strings.Join(fills, sep) + suffix
+}
+
+// writeLines writes lines to w, separated by newlines.
+func writeLines(w io.Writer, lines []string) error {
+	lw := newLineWriter(w)
+	for _, line := range lines {
+		if err := lw.writeLine(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
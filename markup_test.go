@@ -0,0 +1,90 @@
+package colprint
+
+import (
+	"bytes"
+)
+
+func (s *UnitTests) TestFprintLaTeX() {
+	hosts := []dummyHost{{Name: "web_1 & co", Load: 0.375}, {Name: "db", Load: 12}}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintLaTeX(buf, hosts))
+	s.Equal(`\begin{tabular}{lr}
+\hline
+Name         &  Load \\
+\hline
+web\_1 \& co &  0.38 \\
+db           & 12.00 \\
+\hline
+\end{tabular}`, buf.String())
+
+	booktabs := true
+	buf.Reset()
+	s.NoError(FprintLaTeX(buf, hosts[1:], &Config{LaTeXBooktabs: &booktabs}))
+	s.Equal(`\begin{tabular}{lr}
+\toprule
+Name &  Load \\
+\midrule
+db   & 12.00 \\
+\bottomrule
+\end{tabular}`, buf.String())
+}
+
+func (s *UnitTests) TestFprintAsciiDoc() {
+	hosts := []dummyHost{{Name: "web|1", Load: 0.375}, {Name: "db", Load: 12}}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintAsciiDoc(buf, hosts))
+	s.Equal(`[cols="<,>",options="header"]
+|===
+| Name   |  Load
+| web\|1 |  0.38
+| db     | 12.00
+|===`, buf.String())
+}
+
+func (s *UnitTests) TestFprintRST() {
+	hosts := []dummyHost{{Name: "*web*", Load: 0.375}, {Name: "", Load: 12}}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintRST(buf, hosts))
+	s.Equal(`+---------+-------+
+| Name    |  Load |
++=========+=======+
+| \*web\* |  0.38 |
++---------+-------+
+|         | 12.00 |
++---------+-------+`, buf.String())
+
+	simple := true
+	buf.Reset()
+	s.NoError(FprintRST(buf, hosts, &Config{RSTSimple: &simple}))
+	s.Equal(`=======  =====
+Name      Load
+=======  =====
+\*web\*   0.38
+\        12.00
+=======  =====`, buf.String())
+
+	buf.Reset()
+	s.NoError(FprintRST(buf, []dummyHost{}))
+	s.Equal("+------+------+\n| Name | Load |\n+======+======+\n+------+------+", buf.String())
+}
+
+func (s *UnitTests) TestFprintOrg() {
+	hosts := []dummyHost{{Name: "a|b\nc", Load: 0.375}, {Name: "db", Load: 12}}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintOrg(buf, hosts))
+	s.Equal(`| Name        |  Load |
+|-------------+-------|
+| a\vert{}b c |  0.38 |
+| db          | 12.00 |`, buf.String())
+
+	mt := MixedTypesSeparate
+	buf.Reset()
+	s.NoError(FprintOrg(buf, []interface{}{dummyHost{Name: "web"}, dummyProcess{Name: "nginx"}}, &Config{MixedTypes: &mt}))
+	s.Equal(`| Name | Load |
+|------+------|
+| web  | 0.00 |
+
+| Name  | State |
+|-------+-------|
+| nginx |       |`, buf.String())
+}
@@ -0,0 +1,158 @@
+package colprint
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MixedTypes represents how slices holding items of different struct types are printed.
+type MixedTypes int
+
+const (
+	// MixedTypesUnion prints a single table with the union of the columns of all types, matched by label.
+	// Items are printed with blanks for columns their type does not have. Types with explode or tree columns
+	// cannot be printed in a union.
+	MixedTypesUnion MixedTypes = iota
+	// MixedTypesSeparate prints a separate table for each type, in order of first appearance. Nil items are
+	// printed as blank rows in the table of the item before them, or of the first item if there is none.
+	MixedTypesSeparate
+)
+
+// itemValue returns the value held by a slice item, following interfaces and pointers.
+func itemValue(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	return v
+}
+
+// hasMixedTypes reports whether the items of a slice or array are of more than one type.
+func hasMixedTypes(v reflect.Value) bool {
+	var first reflect.Type
+	for i := 0; i < v.Len(); i++ {
+		item := itemValue(v.Index(i))
+		if !item.IsValid() {
+			continue
+		}
+		if first == nil {
+			first = item.Type()
+		} else if item.Type() != first {
+			return true
+		}
+	}
+	return false
+}
+
+// itemRow is a Row holding the cells of a struct printed in a union of types, keeping the struct as the item of
+// its row. The item is nil for the blank rows of nil items.
+type itemRow struct {
+	tableRow
+	item interface{}
+}
+
+// loadMixed adds a slice or array holding items of different struct types, as configured by Config.MixedTypes.
+func (cp *cPrinter) loadMixed(v reflect.Value) ([]*cPrinter, error) {
+	if *cp.config.MixedTypes == MixedTypesSeparate {
+		return cp.loadSeparate(v)
+	}
+	rows := []Row{}
+	typeCols := make(map[reflect.Type]columns)
+	for i := 0; i < v.Len(); i++ {
+		item := itemValue(v.Index(i))
+		if !item.IsValid() {
+			// nil items are printed as blank rows
+			rows = append(rows, itemRow{})
+			continue
+		}
+		cols, ok := typeCols[item.Type()]
+		if !ok {
+			if err := checkStruct(item.Type()); err != nil {
+				return nil, err
+			}
+			tcp := cPrinter{config: cp.config, sample: item}
+			tcp.init()
+			if err := tcp.findColumns(item.Type()); err != nil {
+				return nil, err
+			}
+			if tcp.tree != nil || len(tcp.cols.explodes()) > 0 {
+				return nil, fmt.Errorf("Cannot print the explode or tree columns of %s in a union of types: "+
+					"use MixedTypesSeparate", item.Type())
+			}
+			cols = tcp.cols
+			typeCols[item.Type()] = cols
+		}
+		row := itemRow{item: item.Interface()}
+		for _, col := range cols {
+			cell, err := fieldValue(item, col, nil)
+			if err != nil {
+				return nil, err
+			}
+			row.headers = append(row.headers, col.label)
+			row.cells = append(row.cells, cell)
+		}
+		rows = append(rows, row)
+	}
+	// the paths were evaluated on the structs when finding their columns
+	if err := cp.addRows(rows, nil); err != nil {
+		return nil, err
+	}
+	return []*cPrinter{cp}, nil
+}
+
+// loadSeparate adds the items of a slice or array to a separate cPrinter for each type of item, in order of first
+// appearance. Nil items are added as blank rows to the cPrinter of the item before them, or of the first item.
+func (cp *cPrinter) loadSeparate(v reflect.Value) ([]*cPrinter, error) {
+	printers := []*cPrinter{}
+	types := make(map[reflect.Type]*cPrinter)
+	var last *cPrinter
+	for i := 0; i < v.Len(); i++ {
+		item := itemValue(v.Index(i))
+		if item.IsValid() || last == nil {
+			// leading nil items are printed in the table of the first item
+			t := firstItemType(v)
+			if item.IsValid() {
+				t = item.Type()
+			}
+			tcp, ok := types[t]
+			if !ok {
+				tcp = &cPrinter{config: cp.config, sample: item}
+				if !item.IsValid() {
+					tcp.sample = firstItem(v)
+				}
+				if err := tcp.initColumns(t); err != nil {
+					return nil, err
+				}
+				types[t] = tcp
+				printers = append(printers, tcp)
+			}
+			last = tcp
+		}
+		var s interface{}
+		if item.IsValid() {
+			s = item.Interface()
+		}
+		if err := last.add(s); err != nil {
+			return nil, err
+		}
+	}
+	return printers, nil
+}
+
+// firstItemType returns the type of the first item of a slice or array that is not nil, or nil if all are.
+func firstItemType(v reflect.Value) reflect.Type {
+	if item := firstItem(v); item.IsValid() {
+		return item.Type()
+	}
+	return nil
+}
+
+// firstItem returns the value held by the first item of a slice or array that is not nil, or the zero Value if all
+// are.
+func firstItem(v reflect.Value) reflect.Value {
+	for i := 0; i < v.Len(); i++ {
+		if item := itemValue(v.Index(i)); item.IsValid() {
+			return item
+		}
+	}
+	return reflect.Value{}
+}
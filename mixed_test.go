@@ -0,0 +1,95 @@
+package colprint
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+)
+
+type dummyUser struct {
+	Name  string `colprint:"Name,1"`
+	Email string `colprint:"Email,2"`
+}
+
+type dummyGroup struct {
+	Members int    `colprint:"Members,2"`
+	Name    string `colprint:"Name,1"`
+}
+
+func (s *UnitTests) TestSprint_MixedTypesUnion() {
+	items := []interface{}{
+		dummyUser{Name: "Ola", Email: "ola@example.com"},
+		&dummyGroup{Name: "admins", Members: 3},
+		nil,
+		dummyUser{Name: "Kari"},
+	}
+	val, err := Sprint(items)
+	s.NoError(err)
+	s.Equal("Name    Email            Members\nOla     ola@example.com  \nadmins                   3\n                         \nKari                     ", val)
+}
+
+func (s *UnitTests) TestLoad_MixedTypesUnionItems() {
+	group := &dummyGroup{Name: "admins", Members: 3}
+	items := []interface{}{dummyUser{Name: "Ola"}, group, nil}
+	printers, err := load(items)
+	s.Require().NoError(err)
+	s.Equal([]interface{}{dummyUser{Name: "Ola"}, *group, nil}, printers[0].items)
+
+	classes := []string{}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintHTML(buf, items, &Config{HTMLRowClass: func(item interface{}) string {
+		classes = append(classes, fmt.Sprintf("%T", item))
+		return ""
+	}}))
+	s.Equal([]string{"colprint.dummyUser", "colprint.dummyGroup", "<nil>"}, classes)
+}
+
+func (s *UnitTests) TestFprint_MixedTypesSeparate() {
+	items := []interface{}{
+		dummyUser{Name: "Ola", Email: "ola@example.com"},
+		dummyGroup{Name: "admins", Members: 3},
+		dummyUser{Name: "Kari"},
+	}
+	mt := MixedTypesSeparate
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, items, &Config{MixedTypes: &mt}))
+	s.Equal("Name  Email\nOla   ola@example.com\nKari  \n\nName    Members\nadmins  3", buf.String())
+}
+
+func (s *UnitTests) TestFprint_MixedTypesNilItems() {
+	items := []interface{}{nil, dummyUser{Name: "Ola"}, dummyGroup{Name: "admins", Members: 3}, nil}
+	mt := MixedTypesSeparate
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, items, &Config{MixedTypes: &mt}))
+	s.Equal("Name  Email\n      \nOla   \n\nName    Members\nadmins  3\n        ", buf.String())
+
+	buf.Reset()
+	s.NoError(Fprint(buf, items))
+	s.Equal("Name    Email  Members\n               \nOla            \nadmins         3\n               ", buf.String())
+}
+
+func (s *UnitTests) TestFprint_MixedTypesExplodeAndTree() {
+	items := []interface{}{dummyPod{Name: "web", Containers: []*dummyContainer{{Image: "nginx"}}}, dummyUser{Name: "Ola"}}
+	s.EqualError(Fprint(new(bytes.Buffer), items), "Cannot print the explode or tree columns of colprint.dummyPod "+
+		"in a union of types: use MixedTypesSeparate")
+	s.Error(Fprint(new(bytes.Buffer), []interface{}{dummyUser{}, dummyFile{}}))
+
+	mt := MixedTypesSeparate
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, items, &Config{MixedTypes: &mt}))
+	s.Equal("Namespace  Name  Image  Ports\n           web   nginx  \n\nName  Email\nOla   ", buf.String())
+}
+
+func (s *UnitTests) TestFprint_MixedTypesWithErrors() {
+	items := []interface{}{dummyUser{Name: "Ola"}, Errornous{}}
+	s.Error(Fprint(new(bytes.Buffer), items))
+
+	mt := MixedTypesSeparate
+	s.Error(Fprint(new(bytes.Buffer), items, &Config{MixedTypes: &mt}))
+}
+
+func (s *UnitTests) TestHasMixedTypes() {
+	s.False(hasMixedTypes(reflect.ValueOf([]interface{}{dummyUser{}, &dummyUser{}, nil})))
+	s.True(hasMixedTypes(reflect.ValueOf([]interface{}{dummyUser{}, dummyGroup{}})))
+	s.False(hasMixedTypes(reflect.ValueOf([]dummyUser{})))
+}
@@ -0,0 +1,89 @@
+package colprint
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultPager is the pager used when $PAGER is not set.
+const defaultPager = "less -FRX"
+
+// SprintPages returns the pages of a struct or slice of structs, split by Config.PageSize, each page starting with
+// the header line. The columns have the same widths on all pages. Tables printed separately start new pages.
+func SprintPages(s interface{}, c ...*Config) ([]string, error) {
+	pw := &pageWriter{}
+	err := Fprint(pw, s, c...)
+	return pw.result(), err
+}
+
+// PrintPaged prints a struct or slice of structs to stdout through the pager in $PAGER, or less if not set.
+// When stdout is not a terminal, or the pager cannot be started, it prints directly to stdout.
+func PrintPaged(s interface{}, c ...*Config) error {
+	if !isTerminal(os.Stdout) {
+		return Fprint(os.Stdout, s, c...)
+	}
+	return fprintPaged(os.Stdout, pagerCommand(os.Getenv("PAGER")), s, c...)
+}
+
+// fprintPaged prints a struct or slice of structs through a pager command writing to w. Quitting the pager before
+// all lines are read is not an error.
+func fprintPaged(w io.Writer, cmd *exec.Cmd, s interface{}, c ...*Config) error {
+	cmd.Stdout = w
+	cmd.Stderr = os.Stderr
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return Fprint(w, s, c...)
+	}
+	if err := cmd.Start(); err != nil {
+		return Fprint(w, s, c...)
+	}
+	err = Fprint(in, s, c...)
+	in.Close()
+	if waitErr := cmd.Wait(); err == nil {
+		err = waitErr
+	}
+	if errors.Is(err, ErrBrokenPipe) || errors.Is(err, os.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+// pagerCommand returns the command of the pager, or of less if pager is empty.
+func pagerCommand(pager string) *exec.Cmd {
+	args := strings.Fields(pager)
+	if len(args) == 0 {
+		args = strings.Fields(defaultPager)
+	}
+	return exec.Command(args[0], args[1:]...)
+}
+
+// isTerminal reports whether f is a terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// pageWriter collects the pages written to it as strings.
+type pageWriter struct {
+	pages []string
+	buf   bytes.Buffer
+}
+
+func (pw *pageWriter) Write(p []byte) (int, error) {
+	return pw.buf.Write(p)
+}
+
+// newPage ends the current page and starts a new one.
+func (pw *pageWriter) newPage() {
+	pw.pages = append(pw.pages, pw.buf.String())
+	pw.buf.Reset()
+}
+
+// result returns the pages written, including the current page.
+func (pw *pageWriter) result() []string {
+	return append(pw.pages, pw.buf.String())
+}
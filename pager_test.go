@@ -0,0 +1,74 @@
+package colprint
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+)
+
+func dummyServers(n int) []dummyServer {
+	names := []string{"web", "db", "cache", "queue", "search"}
+	servers := make([]dummyServer, n)
+	for i := range servers {
+		servers[i] = dummyServer{Name: names[i%len(names)]}
+	}
+	return servers
+}
+
+func (s *UnitTests) TestFprint_PageSize() {
+	size := 2
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, dummyServers(5), &Config{PageSize: &size}))
+	s.Equal("Name\nweb\ndb\n\nName\ncache\nqueue\n\nName\nsearch", buf.String())
+}
+
+func (s *UnitTests) TestSprintPages() {
+	size := 2
+	pages, err := SprintPages(dummyServers(3), &Config{PageSize: &size})
+	s.NoError(err)
+	s.Equal([]string{"Name\nweb\ndb", "Name\ncache"}, pages)
+
+	pages, err = SprintPages(dummyServers(3))
+	s.NoError(err)
+	s.Equal([]string{"Name\nweb\ndb\ncache"}, pages)
+
+	pages, err = SprintPages([]dummyServer{}, &Config{PageSize: &size})
+	s.NoError(err)
+	s.Equal([]string{"Name"}, pages)
+
+	mt := MixedTypesSeparate
+	one := 1
+	pages, err = SprintPages([]interface{}{dummyHost{Name: "web"}, dummyProcess{Name: "nginx"}},
+		&Config{MixedTypes: &mt, PageSize: &one})
+	s.NoError(err)
+	s.Equal([]string{"Name  Load\nweb   0.00", "Name   State\nnginx  "}, pages)
+
+	_, err = SprintPages(42)
+	s.Error(err)
+}
+
+func (s *UnitTests) TestFprintPaged() {
+	if _, err := exec.LookPath("cat"); err != nil {
+		s.T().Skip("cat not available")
+	}
+	buf := new(bytes.Buffer)
+	s.NoError(fprintPaged(buf, pagerCommand("cat"), dummyServers(2)))
+	s.Equal("Name\nweb\ndb", buf.String())
+
+	buf.Reset()
+	s.NoError(fprintPaged(buf, pagerCommand("colprint-missing-pager"), dummyServers(2)))
+	s.Equal("Name\nweb\ndb", buf.String())
+}
+
+func (s *UnitTests) TestPagerCommand() {
+	s.Equal([]string{"less", "-FRX"}, pagerCommand("").Args)
+	s.Equal([]string{"more", "-d"}, pagerCommand(" more -d ").Args)
+}
+
+func (s *UnitTests) TestIsTerminal() {
+	f, err := os.CreateTemp("", "colprint")
+	s.NoError(err)
+	defer os.Remove(f.Name())
+	defer f.Close()
+	s.False(isTerminal(f))
+}
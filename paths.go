@@ -0,0 +1,274 @@
+package colprint
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PathColumn is a column holding the value at a path expression, evaluated on the printed structs, maps or rows.
+// Paths are made of steps, each of which is one of:
+//
+//	.name     a struct field, by field name, colprint label or json name, or a map key
+//	['name']  a struct field or map key containing dots or brackets
+//	[n]       element n of a slice or array, counting from the end if negative
+//	[*], .*   all elements of a slice, array or map, or all fields of a struct
+//
+// E.g. ".status.addresses[0].ip" or ".containers[*].image". Paths with wildcards give all matching values.
+type PathColumn struct {
+	// Tag is the label and order of the column, in the same format as the colprint tag, e.g. "IP,2".
+	Tag string
+	// Path is the path expression.
+	Path string
+}
+
+// pathStep is a step of a path expression.
+type pathStep struct {
+	name     string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+// ParsePathColumns parses a columns specification of the form NAME:PATH,NAME:PATH into path columns, ordered as
+// in the specification. Paths are validated when parsed. Commas inside brackets do not separate columns.
+// Example:
+//
+//	NAME:.metadata.name,IP:.status.addresses[0].ip
+func ParsePathColumns(spec string) ([]PathColumn, error) {
+	cols := []PathColumn{}
+	for i, part := range splitOutsideBrackets(spec) {
+		sep := strings.Index(part, ":")
+		if sep <= 0 {
+			return nil, fmt.Errorf("Invalid column %q: expected NAME:PATH", part)
+		}
+		col := PathColumn{Tag: fmt.Sprintf("%s,%d", part[:sep], i), Path: part[sep+1:]}
+		if _, err := parsePath(col.Path); err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// splitOutsideBrackets splits s on commas that are not inside brackets.
+func splitOutsideBrackets(s string) []string {
+	parts := []string{}
+	depth := 0
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '[':
+			depth++
+		case s[i] == ']' && depth > 0:
+			depth--
+		case s[i] == ',' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parsePath parses a path expression into steps. Surrounding braces, as used by kubectl, are allowed.
+func parsePath(path string) ([]pathStep, error) {
+	p := strings.TrimSpace(path)
+	if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+		p = p[1 : len(p)-1]
+	}
+	if p == "" || (p[0] != '.' && p[0] != '[') {
+		return nil, fmt.Errorf("Invalid path %q: must start with . or [", path)
+	}
+	steps := []pathStep{}
+	for len(p) > 0 {
+		switch p[0] {
+		case '.':
+			p = p[1:]
+			end := strings.IndexAny(p, ".[")
+			if end < 0 {
+				end = len(p)
+			}
+			name := p[:end]
+			p = p[end:]
+			switch name {
+			case "":
+				if len(p) > 0 || len(steps) > 0 {
+					return nil, fmt.Errorf("Invalid path %q: empty field name", path)
+				}
+			case "*":
+				steps = append(steps, pathStep{wildcard: true})
+			default:
+				steps = append(steps, pathStep{name: name})
+			}
+		case '[':
+			end := strings.Index(p, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("Invalid path %q: missing ]", path)
+			}
+			sel := p[1:end]
+			p = p[end+1:]
+			if len(sel) >= 2 && (sel[0] == '\'' || sel[0] == '"') && sel[len(sel)-1] == sel[0] {
+				steps = append(steps, pathStep{name: sel[1 : len(sel)-1]})
+			} else if sel == "*" {
+				steps = append(steps, pathStep{wildcard: true})
+			} else if index, err := strconv.Atoi(sel); err == nil {
+				steps = append(steps, pathStep{index: index, isIndex: true})
+			} else {
+				return nil, fmt.Errorf("Invalid path %q: invalid index %q", path, sel)
+			}
+		default:
+			return nil, fmt.Errorf("Invalid path %q: unexpected %q", path, p[0])
+		}
+	}
+	return steps, nil
+}
+
+// evalPath evaluates path steps on v. Returns the value at the path, nil if there is none, or a slice of all
+// matching values if the path has wildcards.
+func evalPath(v reflect.Value, steps []pathStep) interface{} {
+	values := []reflect.Value{v}
+	wildcard := false
+	for _, step := range steps {
+		wildcard = wildcard || step.wildcard
+		next := []reflect.Value{}
+		for _, value := range values {
+			next = append(next, evalStep(itemValue(value), step)...)
+		}
+		values = next
+	}
+
+	results := []interface{}{}
+	for _, value := range values {
+		if value.IsValid() && value.CanInterface() {
+			results = append(results, value.Interface())
+		}
+	}
+	if wildcard {
+		return results
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return results[0]
+}
+
+// evalStep evaluates a path step on v, returning the matching values.
+func evalStep(v reflect.Value, step pathStep) []reflect.Value {
+	switch v.Kind() {
+	case reflect.Struct:
+		if step.wildcard {
+			values := []reflect.Value{}
+			for i := 0; i < v.NumField(); i++ {
+				if v.Type().Field(i).PkgPath == "" {
+					values = append(values, v.Field(i))
+				}
+			}
+			return values
+		}
+		if !step.isIndex {
+			if field, ok := fieldByPathName(v.Type(), step.name); ok {
+				// fields promoted through nil embedded pointers have no value
+				if value := walk(v, field.Index, nil); value.IsValid() {
+					return []reflect.Value{value}
+				}
+			}
+		}
+	case reflect.Map:
+		if step.wildcard {
+			row := newMapRow(v)
+			values := make([]reflect.Value, len(row.keys))
+			for i, key := range row.keys {
+				values[i] = v.MapIndex(key)
+			}
+			return values
+		}
+		name := step.name
+		if step.isIndex {
+			name = strconv.Itoa(step.index)
+		}
+		for _, key := range v.MapKeys() {
+			if fmt.Sprint(key.Interface()) == name {
+				return []reflect.Value{v.MapIndex(key)}
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if step.wildcard {
+			values := make([]reflect.Value, v.Len())
+			for i := range values {
+				values[i] = v.Index(i)
+			}
+			return values
+		}
+		if step.isIndex {
+			index := step.index
+			if index < 0 {
+				index += v.Len()
+			}
+			if index >= 0 && index < v.Len() {
+				return []reflect.Value{v.Index(index)}
+			}
+		}
+	}
+	return nil
+}
+
+// fieldByPathName returns the exported field of struct type t matching name: by field name, colprint label or
+// json name, and finally by field name ignoring case.
+func fieldByPathName(t reflect.Type, name string) (reflect.StructField, bool) {
+	if field, ok := t.FieldByName(name); ok && field.PkgPath == "" {
+		return field, true
+	}
+	var folded *reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		label := strings.Split(field.Tag.Get(TagName), ",")[0]
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if (label == name && !isTraverseTag(field.Tag.Get(TagName))) || jsonName == name {
+			return field, true
+		}
+		if folded == nil && strings.EqualFold(field.Name, name) {
+			folded = &field
+		}
+	}
+	if folded != nil {
+		return *folded, true
+	}
+	return reflect.StructField{}, false
+}
+
+// parsePathColumns parses path columns into columns computing the values at their paths, sorted by order.
+func parsePathColumns(paths []PathColumn) (columns, error) {
+	cols := columns{}
+	for i, pc := range paths {
+		col, err := parseColumnTag(pc.Tag)
+		if err != nil {
+			return nil, &TagError{Field: fmt.Sprintf("Paths[%d]", i), Tag: pc.Tag, Reason: err.Error()}
+		}
+		steps, err := parsePath(pc.Path)
+		if err != nil {
+			return nil, err
+		}
+		col.fieldIndex = &[]int{}
+		col.compute = &computer{name: col.label, fn: func(v reflect.Value) interface{} {
+			return evalPath(v, steps)
+		}}
+		cols = append(cols, col)
+	}
+	sort.Stable(cols)
+	return cols, nil
+}
+
+// appendPathColumns appends the columns of Config.Paths to the list of columns.
+func (cp *cPrinter) appendPathColumns() error {
+	cols, err := parsePathColumns(cp.config.Paths)
+	if err != nil {
+		return err
+	}
+	cp.cols = append(cp.cols, cols...)
+	return nil
+}
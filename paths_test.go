@@ -0,0 +1,126 @@
+package colprint
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+type dummyAddress struct {
+	IP   string `json:"ip"`
+	Port int    `colprint:"Port"`
+}
+
+type dummyStatus struct {
+	Addresses []dummyAddress `json:"addresses"`
+	Phase     string
+}
+
+type dummyAPIObject struct {
+	Name   string            `colprint:"Name,1"`
+	Labels map[string]string `colprint:"-"`
+	Status *dummyStatus      `colprint:"-"`
+}
+
+func (s *UnitTests) TestFprint_PathsOnStructs() {
+	objects := []dummyAPIObject{
+		{
+			Name:   "web",
+			Labels: map[string]string{"app": "nginx", "app.kubernetes.io/tier": "frontend"},
+			Status: &dummyStatus{Phase: "Running", Addresses: []dummyAddress{{IP: "10.0.0.1", Port: 80}, {IP: "10.0.0.2"}}},
+		},
+		{Name: "db"},
+	}
+	paths := []PathColumn{
+		{Tag: "IP,2", Path: ".Status.addresses[0].ip"},
+		{Tag: "Last,3", Path: ".status.addresses[-1].ip"},
+		{Tag: "Ports,4", Path: ".Status.Addresses[*].Port"},
+		{Tag: "Tier,5", Path: ".Labels['app.kubernetes.io/tier']"},
+		{Tag: "Phase", Path: "{.Status.phase}"},
+	}
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, objects, &Config{Paths: paths}))
+	s.Equal("Name  IP        Last      Ports  Tier      Phase\n"+
+		"web   10.0.0.1  10.0.0.2  80, 0  frontend  Running\n"+
+		"db                                         ", buf.String())
+}
+
+func (s *UnitTests) TestFprint_PathsOnMaps() {
+	var items []map[string]interface{}
+	s.NoError(json.Unmarshal([]byte(`[
+		{"metadata": {"name": "web"}, "status": {"addresses": [{"ip": "10.0.0.1"}]}},
+		{"metadata": {"name": "db"}, "status": {}}
+	]`), &items))
+	cols, err := ParsePathColumns("NAME:.metadata.name,IP:.status.addresses[0].ip")
+	s.NoError(err)
+
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, items, &Config{Paths: cols, Columns: []string{"NAME", "IP"}}))
+	s.Equal("NAME  IP\nweb   10.0.0.1\ndb    ", buf.String())
+}
+
+func (s *UnitTests) TestFprint_PathsOnTable() {
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, dummyTable{}, &Config{Paths: []PathColumn{{Tag: "Who", Path: ".Name"}}}))
+	s.Equal("Name  Age  Who\nOla   35   Ola\nKari  37   Kari", buf.String())
+}
+
+func (s *UnitTests) TestFprint_PathsThroughNilEmbeddedPointer() {
+	type meta struct {
+		Name string
+	}
+	type resource struct {
+		*meta
+		Kind string `colprint:"Kind,1"`
+	}
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, []resource{{Kind: "x"}, {meta: &meta{Name: "web"}, Kind: "y"}},
+		&Config{Paths: []PathColumn{{Tag: "N,2", Path: ".Name"}}}))
+	s.Equal("Kind  N\nx     \ny     web", buf.String())
+}
+
+func (s *UnitTests) TestParsePathColumns() {
+	cols, err := ParsePathColumns("NAME:.metadata.name,IP:.status.addresses[0].ip")
+	s.NoError(err)
+	s.Equal([]PathColumn{{Tag: "NAME,0", Path: ".metadata.name"}, {Tag: "IP,1", Path: ".status.addresses[0].ip"}}, cols)
+
+	cols, err = ParsePathColumns("TIER:.labels['app,tier'],NAME:.name")
+	s.NoError(err)
+	s.Equal([]PathColumn{{Tag: "TIER,0", Path: ".labels['app,tier']"}, {Tag: "NAME,1", Path: ".name"}}, cols)
+
+	_, err = ParsePathColumns("NAME")
+	s.Error(err)
+	_, err = ParsePathColumns("NAME:metadata")
+	s.Error(err)
+}
+
+func (s *UnitTests) TestParsePath() {
+	steps, err := parsePath(".a['b.c'][2].*[*]")
+	s.NoError(err)
+	s.Equal([]pathStep{
+		{name: "a"},
+		{name: "b.c"},
+		{index: 2, isIndex: true},
+		{wildcard: true},
+		{wildcard: true},
+	}, steps)
+
+	for _, invalid := range []string{"", "a", ".a..b", ".a[1", ".a[x]"} {
+		_, err := parsePath(invalid)
+		s.Error(err, invalid)
+	}
+}
+
+func (s *UnitTests) TestEvalPath() {
+	data := map[string]interface{}{"items": []interface{}{map[string]interface{}{"n": 1}, map[string]interface{}{"n": 2}}}
+	eval := func(path string) interface{} {
+		steps, err := parsePath(path)
+		s.NoError(err)
+		return evalPath(reflect.ValueOf(data), steps)
+	}
+	s.Equal(1, eval(".items[0].n"))
+	s.Equal([]interface{}{1, 2}, eval(".items[*].n"))
+	s.Nil(eval(".items[5].n"))
+	s.Nil(eval(".missing"))
+	s.Equal([]interface{}{}, eval(".missing[*]"))
+}
@@ -0,0 +1,215 @@
+package colprint
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+// TableModel is a table resolved for rendering, holding the columns and the rows left after filtering and sorting.
+// The values are not truncated by Config.MaxColumnWidth, which renderers of text layouts apply themselves.
+type TableModel struct {
+	Columns []ColumnModel
+	Rows    []RowModel
+	// Config is the config the table is printed with, merged with the default config.
+	Config *Config
+}
+
+// ColumnModel is a column of a TableModel.
+type ColumnModel struct {
+	Label string
+	// Type is the type of the raw values of the column, or nil if they are of different types or all nil.
+	Type reflect.Type
+	// Class is the class set by the class tag option, if any.
+	Class string
+	// SQLName is the name set by the sql tag option, if any.
+	SQLName string
+}
+
+// RowModel is a row of a TableModel.
+type RowModel struct {
+	// Item is the struct, map or Row the row was added from, or nil for blank rows.
+	Item interface{}
+	// Raw holds the values of the cells before formatting, in column order.
+	Raw []interface{}
+	// Values holds the formatted values of the cells, in column order.
+	Values []string
+}
+
+// Renderer renders tables in an output format. Fprint renders each table it prints, separated by blank lines, with
+// the Renderer selected by Config.Format.
+type Renderer interface {
+	// Render writes the table to w. The output should not end with a newline.
+	Render(w io.Writer, t *TableModel) error
+}
+
+// RendererFunc is a function used as a Renderer.
+type RendererFunc func(w io.Writer, t *TableModel) error
+
+// Render calls f(w, t).
+func (f RendererFunc) Render(w io.Writer, t *TableModel) error {
+	return f(w, t)
+}
+
+// TablesRenderer is a Renderer rendering all the tables Fprint prints at once, e.g. as a single document, instead
+// of each table separated by blank lines.
+type TablesRenderer interface {
+	Renderer
+	// RenderTables writes the tables to w.
+	RenderTables(w io.Writer, tables []*TableModel) error
+}
+
+// ContentTyper is implemented by Renderers whose output Handler serves with another content type than
+// text/plain, and negotiates by the Accept header.
+type ContentTyper interface {
+	// ContentType returns the content type of the output, e.g. "text/html; charset=utf-8".
+	ContentType() string
+}
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = map[string]Renderer{
+		"text":     textRenderer{},
+		"html":     htmlRenderer{},
+		"csv":      csvRenderer{},
+		"json":     jsonRenderer{},
+		"yaml":     yamlRenderer{},
+		"xlsx":     xlsxRenderer{},
+		"sql":      sqlRenderer{},
+		"copy":     copyRenderer{},
+		"latex":    latexRenderer{},
+		"asciidoc": asciiDocRenderer{},
+		"rst":      rstRenderer{},
+		"org":      orgRenderer{},
+	}
+)
+
+// RegisterRenderer registers a Renderer under name, to be selected with Config.Format. Registering a renderer
+// under the name of another renderer replaces it.
+func RegisterRenderer(name string, r Renderer) {
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	renderers[name] = r
+}
+
+// LookupRenderer returns the Renderer registered under name, and whether there is one.
+func LookupRenderer(name string) (Renderer, bool) {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	r, ok := renderers[name]
+	return r, ok
+}
+
+// RendererNames returns the names of the registered renderers, sorted.
+func RendererNames() []string {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupRenderer returns the Renderer registered under name, or an error if there is none.
+func lookupRenderer(name string) (Renderer, error) {
+	r, ok := LookupRenderer(name)
+	if !ok {
+		return nil, fmt.Errorf("Unknown format %s", name)
+	}
+	return r, nil
+}
+
+// modelPrinter returns a printer of the config of t, formatting the raw values of t like the printer of t.
+func modelPrinter(t *TableModel) *cPrinter {
+	return &cPrinter{config: t.Config}
+}
+
+// model returns the table of the printer as a TableModel.
+func (cp *cPrinter) model() *TableModel {
+	t := &TableModel{Config: cp.config}
+	for _, col := range cp.cols {
+		t.Columns = append(t.Columns,
+			ColumnModel{Label: col.label, Type: cp.columnType(col), Class: col.class, SQLName: col.sqlName})
+	}
+	for i := 0; i < cp.itemCount; i++ {
+		row := RowModel{Item: cp.items[i], Raw: make([]interface{}, len(cp.cols)), Values: cp.row(i)}
+		for j, col := range cp.cols {
+			row.Raw[j] = cp.raw[col][i]
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+// columnType returns the type of the raw values of the column, or nil if they are of different types or all nil.
+func (cp *cPrinter) columnType(col column) reflect.Type {
+	var t reflect.Type
+	for _, raw := range cp.raw[col] {
+		switch rt := reflect.TypeOf(raw); {
+		case rt == nil:
+		case t == nil:
+			t = rt
+		case t != rt:
+			return nil
+		}
+	}
+	return t
+}
+
+// textRenderer renders tables as columns of plain text, aligned by padding the values to the width of their
+// column and truncated to Config.MaxColumnWidth. With Config.PageSize set, the rows are split into pages, each
+// starting with the header line.
+type textRenderer struct{}
+
+func (textRenderer) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+func (textRenderer) Render(w io.Writer, t *TableModel) error {
+	lw := newLineWriter(w)
+	widths := make([]int, len(t.Columns))
+	headers := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		headers[i] = col.Label
+		widths[i] = utf8.RuneCountInString(col.Label)
+	}
+	rows := make([][]string, len(t.Rows))
+	for r, row := range t.Rows {
+		rows[r] = truncateValues(row.Values, *t.Config.MaxColumnWidth)
+		for i, val := range rows[r] {
+			if l := utf8.RuneCountInString(val); l > widths[i] {
+				widths[i] = l
+			}
+		}
+	}
+
+	size := *t.Config.PageSize
+	if size <= 0 || size > len(t.Rows) {
+		size = len(t.Rows)
+	}
+	for start := 0; ; {
+		end := start + size
+		if end > len(t.Rows) {
+			end = len(t.Rows)
+		}
+		if err := lw.writeLine(formatLine(headers, widths)); err != nil {
+			return err
+		}
+		for _, row := range rows[start:end] {
+			if err := lw.writeLine(formatLine(row, widths)); err != nil {
+				return err
+			}
+		}
+		if start = end; start >= len(t.Rows) {
+			return nil
+		}
+		if err := lw.pageBreak(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package colprint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+func (s *UnitTests) TestRegisterRenderer() {
+	RegisterRenderer("wiki", RendererFunc(func(w io.Writer, t *TableModel) error {
+		labels := []string{}
+		for _, col := range t.Columns {
+			labels = append(labels, col.Label)
+		}
+		lines := []string{"||" + strings.Join(labels, "||") + "||"}
+		for _, row := range t.Rows {
+			lines = append(lines, "|"+strings.Join(row.Values, "|")+"|")
+		}
+		_, err := io.WriteString(w, strings.Join(lines, "\n"))
+		return err
+	}))
+	defer func() {
+		renderersMu.Lock()
+		delete(renderers, "wiki")
+		renderersMu.Unlock()
+	}()
+	s.Contains(RendererNames(), "wiki")
+	r, ok := LookupRenderer("wiki")
+	s.True(ok)
+	s.NotNil(r)
+
+	format := "wiki"
+	mt := MixedTypesSeparate
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, []interface{}{dummyHost{Name: "web", Load: 0.375}, dummyProcess{Name: "nginx"}},
+		&Config{Format: &format, MixedTypes: &mt}))
+	s.Equal("||Name||Load||\n|web|0.38|\n\n||Name||State||\n|nginx||", buf.String())
+
+	format = "unknown"
+	s.EqualError(Fprint(buf, []dummyHost{}, &Config{Format: &format}), "Unknown format unknown")
+}
+
+// dummyTablesRenderer renders the number of tables it renders at once.
+type dummyTablesRenderer struct{}
+
+func (r dummyTablesRenderer) Render(w io.Writer, t *TableModel) error {
+	return r.RenderTables(w, []*TableModel{t})
+}
+
+func (dummyTablesRenderer) RenderTables(w io.Writer, tables []*TableModel) error {
+	_, err := fmt.Fprintf(w, "%d tables", len(tables))
+	return err
+}
+
+func (s *UnitTests) TestFprint_TablesRenderer() {
+	RegisterRenderer("count", dummyTablesRenderer{})
+	defer func() {
+		renderersMu.Lock()
+		delete(renderers, "count")
+		renderersMu.Unlock()
+	}()
+	format := "count"
+	mt := MixedTypesSeparate
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, []interface{}{dummyHost{}, dummyProcess{}}, &Config{Format: &format, MixedTypes: &mt}))
+	s.Equal("2 tables", buf.String())
+}
+
+func (s *UnitTests) TestFprint_Formats() {
+	fprints := map[string]func(w io.Writer, s interface{}, c ...*Config) error{
+		"html": FprintHTML,
+		"csv":  FprintCSV,
+		"json": FprintJSON,
+		"yaml": FprintYAML,
+		"xlsx": FprintXLSX,
+		"sql": func(w io.Writer, s interface{}, c ...*Config) error {
+			return FprintSQL(w, s, "hosts", c...)
+		},
+		"copy": func(w io.Writer, s interface{}, c ...*Config) error {
+			return FprintCopy(w, s, "hosts", c...)
+		},
+	}
+	hosts := []interface{}{dummyHost{Name: "web", Load: 0.375}, dummyProcess{Name: "nginx"}}
+	mt := MixedTypesSeparate
+	table := "hosts"
+	for name, fprint := range fprints {
+		format := name
+		want, got := new(bytes.Buffer), new(bytes.Buffer)
+		s.NoError(fprint(want, hosts, &Config{MixedTypes: &mt}), name)
+		s.NoError(Fprint(got, hosts, &Config{Format: &format, MixedTypes: &mt, SQLTable: &table}), name)
+		s.Equal(want.String(), got.String(), name)
+	}
+
+	format := "sql"
+	s.EqualError(Fprint(new(bytes.Buffer), hosts, &Config{Format: &format}), "Missing SQL table: set Config.SQLTable")
+}
+
+func (s *UnitTests) TestRenderer_Errors() {
+	RegisterRenderer("failing", RendererFunc(func(w io.Writer, t *TableModel) error {
+		return fmt.Errorf("failed on %d rows", len(t.Rows))
+	}))
+	defer func() {
+		renderersMu.Lock()
+		delete(renderers, "failing")
+		renderersMu.Unlock()
+	}()
+	format := "failing"
+	s.EqualError(Fprint(new(bytes.Buffer), []dummyHost{{}}, &Config{Format: &format}), "failed on 1 rows")
+}
+
+func (s *UnitTests) TestCPrinter_model() {
+	manager := "kari"
+	fixtures := []dummyFixture{{ID: 1, Name: "ola", Manager: &manager}, {ID: 2, Name: "per"}}
+	printers, err := load(fixtures, &Config{Columns: []string{"ID", "Full name", "Manager"}})
+	s.Require().NoError(err)
+	model := printers[0].model()
+
+	s.Equal(printers[0].config, model.Config)
+	s.Equal([]ColumnModel{
+		{Label: "ID", Type: reflect.TypeOf(0), SQLName: "id"},
+		{Label: "Full name", Type: reflect.TypeOf(""), SQLName: "full_name"},
+		{Label: "Manager", Type: reflect.TypeOf(&manager)},
+	}, model.Columns)
+	s.Equal([]RowModel{
+		{Item: fixtures[0], Raw: []interface{}{1, "ola", &manager}, Values: []string{"1", "ola", "kari"}},
+		{Item: fixtures[1], Raw: []interface{}{2, "per", (*string)(nil)}, Values: []string{"2", "per", ""}},
+	}, model.Rows)
+	s.True(numericColumn(model, 0))
+	s.False(numericColumn(model, 1))
+	s.False(numericColumn(model, 2))
+
+	rows := []Row{tableRow{headers: []string{"a"}, cells: []interface{}{1}},
+		tableRow{headers: []string{"a"}, cells: []interface{}{"x"}}}
+	printers, err = load(rows)
+	s.Require().NoError(err)
+	model = printers[0].model()
+	s.Nil(model.Columns[0].Type)
+	s.False(numericColumn(model, 0))
+}
@@ -0,0 +1,230 @@
+package colprint
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Table is implemented by data sources that supply their own headers and cell values.
+type Table interface {
+	// Headers returns the column headers in print order.
+	Headers() []string
+	// Len returns the number of rows in the table.
+	Len() int
+	// Cells returns the cell values of row i, in the same order as Headers.
+	Cells(i int) []interface{}
+}
+
+// Row is implemented by items that supply their own headers and cell values. When printing a slice of rows,
+// the columns are the union of the headers of all rows. Headers repeated in a row, e.g. the id columns of a join,
+// are suffixed by their occurrence: id, id_2 and so on.
+type Row interface {
+	// Headers returns the column headers of the row.
+	Headers() []string
+	// Cells returns the cell values of the row, in the same order as Headers.
+	Cells() []interface{}
+}
+
+// tableRow is a Row holding a single row of a Table.
+type tableRow struct {
+	headers []string
+	cells   []interface{}
+}
+
+func (r tableRow) Headers() []string {
+	return r.headers
+}
+
+func (r tableRow) Cells() []interface{} {
+	return r.cells
+}
+
+// mapRow is a Row backed by a map. The headers are the map keys in sorted order.
+type mapRow struct {
+	keys []reflect.Value
+	m    reflect.Value
+}
+
+// newMapRow creates a mapRow from a value of kind reflect.Map.
+func newMapRow(m reflect.Value) mapRow {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return mapRow{keys: keys, m: m}
+}
+
+func (r mapRow) Headers() []string {
+	headers := make([]string, len(r.keys))
+	for i, key := range r.keys {
+		headers[i] = fmt.Sprint(key.Interface())
+	}
+	return headers
+}
+
+func (r mapRow) Cells() []interface{} {
+	cells := make([]interface{}, len(r.keys))
+	for i, key := range r.keys {
+		cells[i] = r.m.MapIndex(key).Interface()
+	}
+	return cells
+}
+
+// rowOf returns v as a Row if it is a map or implements Row.
+func rowOf(v reflect.Value) (Row, bool) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, false
+	}
+	if r, ok := v.Interface().(Row); ok {
+		return r, true
+	}
+	if v.Kind() == reflect.Map {
+		return newMapRow(v), true
+	}
+	return nil, false
+}
+
+// rowsOf returns the items of a slice or array as rows if every item is a map or implements Row.
+// An empty slice is treated as rows if its element type is a map or implements Row.
+func rowsOf(v reflect.Value) ([]Row, bool) {
+	if v.Len() == 0 {
+		elem := v.Type().Elem()
+		return nil, elem.Kind() == reflect.Map || elem.Implements(reflect.TypeOf((*Row)(nil)).Elem())
+	}
+	rows := make([]Row, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		r, ok := rowOf(v.Index(i))
+		if !ok {
+			return nil, false
+		}
+		rows[i] = r
+	}
+	return rows, true
+}
+
+// rowsOfTable returns the rows of a Table.
+func rowsOfTable(t Table) []Row {
+	headers := t.Headers()
+	rows := make([]Row, t.Len())
+	for i := range rows {
+		rows[i] = tableRow{headers: headers, cells: t.Cells(i)}
+	}
+	return rows
+}
+
+// addRows adds dynamic rows to the printer. The columns are Config.Columns if set, otherwise the union of the
+// row headers in order of first appearance followed by the path columns. Returns an error if a path is invalid.
+func (cp *cPrinter) addRows(rows []Row, paths []PathColumn) error {
+	cp.init()
+	pathCols, err := parsePathColumns(paths)
+	if err != nil {
+		return err
+	}
+	labels := cp.config.Columns
+	if labels == nil {
+		labels = []string{}
+		for _, row := range rows {
+			labels = append(labels, distinctHeaders(row.Headers())...)
+		}
+		for _, col := range pathCols {
+			labels = append(labels, col.label)
+		}
+	}
+	for i, label := range uniqueLabels(labels) {
+		col := column{label: label, order: i}
+		for _, pathCol := range pathCols {
+			if pathCol.label == label {
+				col.compute = pathCol.compute
+				col.class = pathCol.class
+				col.sqlName = pathCol.sqlName
+			}
+		}
+		cp.cols = append(cp.cols, col)
+		cp.initColumn(col)
+	}
+
+	for _, row := range rows {
+		cells := make(map[string]interface{})
+		values := row.Cells()
+		for i, header := range distinctHeaders(row.Headers()) {
+			if i < len(values) {
+				cells[header] = values[i]
+			}
+		}
+		raw := make([]interface{}, len(cp.cols))
+		vals := make([]string, len(cp.cols))
+		for i, col := range cp.cols {
+			if col.compute != nil {
+				raw[i] = col.compute.fn(rowData(row, cells))
+			} else {
+				raw[i] = cells[col.label]
+			}
+			vals[i] = cp.valueOf(raw[i])
+		}
+		cp.appendRow(rowItem(row), raw, vals)
+	}
+	return nil
+}
+
+// rowItem returns the item a row was added from: the struct of an itemRow, the map of a map row, and otherwise the
+// row itself.
+func rowItem(row Row) interface{} {
+	switch r := row.(type) {
+	case itemRow:
+		return r.item
+	case mapRow:
+		return r.m.Interface()
+	}
+	return row
+}
+
+// rowData returns the data of a row for evaluating paths: the map of a map row, and otherwise the cells of the
+// row keyed by header.
+func rowData(row Row, cells map[string]interface{}) reflect.Value {
+	if m, ok := row.(mapRow); ok {
+		return m.m
+	}
+	return reflect.ValueOf(cells)
+}
+
+// distinctHeaders returns the headers of a row with repeated headers suffixed by their occurrence, e.g. id and
+// id_2, skipping suffixed names that are headers of the row already.
+func distinctHeaders(headers []string) []string {
+	taken := make(map[string]bool, len(headers))
+	for _, header := range headers {
+		taken[header] = true
+	}
+	seen := make(map[string]int, len(headers))
+	distinct := make([]string, len(headers))
+	for i, header := range headers {
+		seen[header]++
+		distinct[i] = header
+		if seen[header] == 1 {
+			continue
+		}
+		n := seen[header]
+		for taken[fmt.Sprintf("%s_%d", header, n)] {
+			n++
+		}
+		distinct[i] = fmt.Sprintf("%s_%d", header, n)
+		taken[distinct[i]] = true
+	}
+	return distinct
+}
+
+// uniqueLabels returns labels without duplicates, keeping the first occurrence of each label.
+func uniqueLabels(labels []string) []string {
+	seen := make(map[string]bool)
+	unique := []string{}
+	for _, label := range labels {
+		if !seen[label] {
+			seen[label] = true
+			unique = append(unique, label)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,116 @@
+package colprint
+
+import (
+	"bytes"
+)
+
+type dummyTable struct{}
+
+func (dummyTable) Headers() []string {
+	return []string{"Name", "Age"}
+}
+
+func (dummyTable) Len() int {
+	return 2
+}
+
+func (dummyTable) Cells(i int) []interface{} {
+	return [][]interface{}{{"Ola", 35}, {"Kari", 37}}[i]
+}
+
+type dummyRow struct {
+	headers []string
+	cells   []interface{}
+}
+
+func (r dummyRow) Headers() []string {
+	return r.headers
+}
+
+func (r dummyRow) Cells() []interface{} {
+	return r.cells
+}
+
+func (s *UnitTests) TestSprint_Maps() {
+	rows := []map[string]interface{}{
+		{"name": "Ola", "age": 35},
+		{"name": "Kari", "email": "kari@example.com", "age": nil},
+	}
+	val, err := Sprint(rows)
+	s.NoError(err)
+	s.Equal("age  name  email\n35   Ola   \n     Kari  kari@example.com", val)
+}
+
+func (s *UnitTests) TestSprint_InterfaceSliceOfMaps() {
+	rows := []interface{}{
+		map[string]interface{}{"b": 1.5, "a": true},
+		map[string]string{"c": "x"},
+	}
+	val, err := Sprint(rows)
+	s.NoError(err)
+	s.Equal("a     b     c\ntrue  1.50  \n            x", val)
+}
+
+func (s *UnitTests) TestSprint_SingleMap() {
+	val, err := Sprint(map[string]int{"b": 2, "a": 1})
+	s.NoError(err)
+	s.Equal("a  b\n1  2", val)
+}
+
+func (s *UnitTests) TestFprint_MapsWithColumns() {
+	rows := []map[string]interface{}{
+		{"name": "Ola", "age": 35},
+		{"name": "Kari", "age": 37},
+	}
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, rows, &Config{Columns: []string{"name", "missing", "age"}}))
+	s.Equal("name  missing  age\nOla            35\nKari           37", buf.String())
+}
+
+func (s *UnitTests) TestSprint_EmptyMapSlice() {
+	val, err := Sprint([]map[string]interface{}{})
+	s.NoError(err)
+	s.Empty(val)
+}
+
+func (s *UnitTests) TestSprint_Table() {
+	val, err := Sprint(dummyTable{})
+	s.NoError(err)
+	s.Equal("Name  Age\nOla   35\nKari  37", val)
+}
+
+func (s *UnitTests) TestSprint_Rows() {
+	rows := []dummyRow{
+		{headers: []string{"Name"}, cells: []interface{}{"Ola"}},
+		{headers: []string{"Group", "Name"}, cells: []interface{}{"admin", "Kari"}},
+	}
+	val, err := Sprint(rows)
+	s.NoError(err)
+	s.Equal("Name  Group\nOla   \nKari  admin", val)
+}
+
+func (s *UnitTests) TestSprint_RowsWithRepeatedHeaders() {
+	rows := []dummyRow{{headers: []string{"id", "name", "id"}, cells: []interface{}{1, "Ola", 7}}}
+	val, err := Sprint(rows)
+	s.NoError(err)
+	s.Equal("id  name  id_2\n1   Ola   7", val)
+}
+
+func (s *UnitTests) TestDistinctHeaders() {
+	s.Equal([]string{"id", "name", "id_2", "id_3"}, distinctHeaders([]string{"id", "name", "id", "id"}))
+	s.Equal([]string{"a", "a_2", "a_3"}, distinctHeaders([]string{"a", "a_2", "a"}))
+	s.Equal([]string{}, distinctHeaders(nil))
+}
+
+func (s *UnitTests) TestUniqueLabels() {
+	s.Equal([]string{"a", "b", "c"}, uniqueLabels([]string{"a", "b", "a", "c", "b"}))
+	s.Equal([]string{}, uniqueLabels(nil))
+}
+
+func (s *UnitTests) TestLoad_RowItems() {
+	m := map[string]interface{}{"name": "Ola"}
+	row := dummyRow{headers: []string{"name"}, cells: []interface{}{"Kari"}}
+	printers, err := load([]interface{}{m, row})
+	s.Require().NoError(err)
+	s.Equal([]interface{}{m, row}, printers[0].items)
+}
@@ -0,0 +1,76 @@
+package colprint
+
+import (
+	"database/sql"
+	"io"
+	"reflect"
+)
+
+// FprintRows prints the result of a database query to provided io.Writer using provided config. The column
+// headers are the column names of the result, with repeated names suffixed by their occurrence, e.g. id and id_2
+// for a join selecting a.id and b.id. NULL values are printed as blanks. Rows are read until exhausted,
+// but are not closed.
+// If config is nil, default config will be used.
+func FprintRows(w io.Writer, rows *sql.Rows, c ...*Config) error {
+	var conf *Config
+	if len(c) > 0 {
+		conf = c[0]
+	}
+	cp := cPrinter{config: mergeConfig(createDefaultConfig(), conf)}
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	headers := make([]string, len(types))
+	for i, t := range types {
+		headers[i] = t.Name()
+	}
+
+	items := []Row{}
+	for rows.Next() {
+		dest := scanDestinations(types)
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		cells := make([]interface{}, len(dest))
+		for i, d := range dest {
+			cells[i] = reflect.ValueOf(d).Elem().Interface()
+		}
+		items = append(items, tableRow{headers: headers, cells: cells})
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if err := cp.addRows(items, cp.config.Paths); err != nil {
+		return err
+	}
+	if err := cp.arrange(); err != nil {
+		return err
+	}
+	r, err := lookupRenderer(*cp.config.Format)
+	if err != nil {
+		return err
+	}
+	return fprintAll(w, []*cPrinter{&cp}, r)
+}
+
+// rawBytesType is the type of sql.RawBytes.
+var rawBytesType = reflect.TypeOf(sql.RawBytes(nil))
+
+// scanDestinations creates values to scan a row into, based on the scan types of the columns. Values are scanned
+// into pointers, so that NULL is scanned as a nil pointer for any type. sql.RawBytes columns are scanned into
+// []byte, as the memory of RawBytes is owned by the driver and overwritten by the next row.
+func scanDestinations(types []*sql.ColumnType) []interface{} {
+	dest := make([]interface{}, len(types))
+	for i, t := range types {
+		scanType := t.ScanType()
+		if scanType == nil || scanType.Kind() == reflect.Interface {
+			dest[i] = new(interface{})
+		} else if scanType == rawBytesType {
+			dest[i] = new(*[]byte)
+		} else {
+			dest[i] = reflect.New(reflect.PtrTo(scanType)).Interface()
+		}
+	}
+	return dest
+}
@@ -0,0 +1,199 @@
+package colprint
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+)
+
+func init() {
+	sql.Register("colprintstub", stubDriver{})
+}
+
+// stubDriver is an in-memory database/sql driver returning a fixed result for any query.
+type stubDriver struct{}
+
+func (stubDriver) Open(name string) (driver.Conn, error) {
+	return stubConn{fail: name == "fail", rawBytes: name == "rawbytes"}, nil
+}
+
+type stubConn struct {
+	fail     bool
+	rawBytes bool
+}
+
+func (c stubConn) Prepare(query string) (driver.Stmt, error) {
+	return stubStmt{fail: c.fail, rawBytes: c.rawBytes}, nil
+}
+
+func (stubConn) Close() error {
+	return nil
+}
+
+func (stubConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions not supported")
+}
+
+type stubStmt struct {
+	fail     bool
+	rawBytes bool
+}
+
+func (stubStmt) Close() error {
+	return nil
+}
+
+func (stubStmt) NumInput() int {
+	return -1
+}
+
+func (stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec not supported")
+}
+
+func (s stubStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if s.rawBytes {
+		return &stubRawRows{buf: make([]byte, 5)}, nil
+	}
+	return &stubRows{fail: s.fail}, nil
+}
+
+type stubRows struct {
+	fail bool
+	pos  int
+}
+
+var stubData = [][]driver.Value{
+	{int64(1), "Ola", []byte("admin"), nil, 1.5},
+	{int64(2), "Kari", nil, "note", nil},
+}
+
+func (*stubRows) Columns() []string {
+	return []string{"id", "name", "role", "note", "score"}
+}
+
+func (*stubRows) ColumnTypeScanType(i int) reflect.Type {
+	return []reflect.Type{
+		reflect.TypeOf(int64(0)),
+		reflect.TypeOf(""),
+		reflect.TypeOf([]byte(nil)),
+		reflect.TypeOf(sql.NullString{}),
+		reflect.TypeOf(new(interface{})).Elem(),
+	}[i]
+}
+
+func (*stubRows) Close() error {
+	return nil
+}
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if r.pos == len(stubData) {
+		return io.EOF
+	}
+	if r.fail {
+		return errors.New("connection lost")
+	}
+	copy(dest, stubData[r.pos])
+	r.pos++
+	return nil
+}
+
+// stubRawRows returns rows of a column of sql.RawBytes scan type, reusing the same buffer for all rows like
+// drivers do.
+type stubRawRows struct {
+	buf []byte
+	pos int
+}
+
+func (*stubRawRows) Columns() []string {
+	return []string{"role"}
+}
+
+func (*stubRawRows) ColumnTypeScanType(i int) reflect.Type {
+	return reflect.TypeOf(sql.RawBytes(nil))
+}
+
+func (*stubRawRows) Close() error {
+	return nil
+}
+
+func (r *stubRawRows) Next(dest []driver.Value) error {
+	roles := []string{"admin", "guest"}
+	if r.pos == len(roles) {
+		return io.EOF
+	}
+	copy(r.buf, roles[r.pos])
+	dest[0] = r.buf
+	r.pos++
+	return nil
+}
+
+func (s *UnitTests) TestFprintRows() {
+	db, err := sql.Open("colprintstub", "")
+	s.NoError(err)
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM users")
+	s.NoError(err)
+	defer rows.Close()
+
+	buf := new(bytes.Buffer)
+	s.NoError(FprintRows(buf, rows))
+	s.Equal("id  name  role   note  score\n1   Ola   admin        1.50\n2   Kari         note  ", buf.String())
+}
+
+func (s *UnitTests) TestFprintRows_WithColumns() {
+	db, err := sql.Open("colprintstub", "")
+	s.NoError(err)
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM users")
+	s.NoError(err)
+	defer rows.Close()
+
+	buf := new(bytes.Buffer)
+	s.NoError(FprintRows(buf, rows, &Config{Columns: []string{"name", "id"}}))
+	s.Equal("name  id\nOla   1\nKari  2", buf.String())
+}
+
+func (s *UnitTests) TestFprintRows_RawBytes() {
+	db, err := sql.Open("colprintstub", "rawbytes")
+	s.NoError(err)
+	defer db.Close()
+
+	rows, err := db.Query("SELECT role FROM users")
+	s.NoError(err)
+	defer rows.Close()
+
+	buf := new(bytes.Buffer)
+	s.NoError(FprintRows(buf, rows))
+	s.Equal("role\nadmin\nguest", buf.String())
+}
+
+func (s *UnitTests) TestFprintRows_Error() {
+	db, err := sql.Open("colprintstub", "fail")
+	s.NoError(err)
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM users")
+	s.NoError(err)
+	defer rows.Close()
+
+	s.Error(FprintRows(new(bytes.Buffer), rows))
+}
+
+func (s *UnitTests) TestValueOf_SQLTypes() {
+	cp := cPrinter{config: createDefaultConfig()}
+	s.Equal("text", cp.valueOf(sql.NullString{String: "text", Valid: true}))
+	s.Equal("", cp.valueOf(sql.NullString{String: "text"}))
+	s.Equal("", cp.valueOf((*sql.NullInt64)(nil)))
+	s.Equal("42", cp.valueOf(sql.NullInt64{Int64: 42, Valid: true}))
+	s.Equal("bytes", cp.valueOf([]byte("bytes")))
+	s.Equal("raw", cp.valueOf(sql.RawBytes("raw")))
+	s.Equal(`{"a":1}`, cp.valueOf(json.RawMessage(`{"a":1}`)))
+	s.Equal("7", cp.valueOf(uint8(7)))
+}
@@ -0,0 +1,297 @@
+package colprint
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// SQLDialect represents how identifiers and values are quoted in SQL output.
+type SQLDialect int
+
+const (
+	// SQLDialectANSI quotes identifiers in double quotes and prints booleans as TRUE and FALSE.
+	SQLDialectANSI SQLDialect = iota
+	// SQLDialectPostgres quotes identifiers in double quotes and prints booleans as TRUE and FALSE.
+	SQLDialectPostgres
+	// SQLDialectMySQL quotes identifiers in backticks, escapes backslashes in strings and prints times without
+	// offset.
+	SQLDialectMySQL
+	// SQLDialectSQLite quotes identifiers in double quotes and prints booleans as 1 and 0.
+	SQLDialectSQLite
+	// SQLDialectSQLServer quotes identifiers in brackets, prints strings as N'...' and booleans as 1 and 0.
+	SQLDialectSQLServer
+)
+
+// sqlDialect holds how a dialect quotes identifiers and prints values.
+type sqlDialect struct {
+	quoteIdent  func(s string) string
+	quoteString func(s string) string
+	boolValues  [2]string
+	timeLayout  string
+}
+
+// sqlDialects are the sqlDialects of the SQLDialect values.
+var sqlDialects = map[SQLDialect]sqlDialect{
+	SQLDialectANSI:      {quoteDouble, quoteSingle, [2]string{"FALSE", "TRUE"}, "2006-01-02 15:04:05.999999999Z07:00"},
+	SQLDialectPostgres:  {quoteDouble, quoteSingle, [2]string{"FALSE", "TRUE"}, "2006-01-02 15:04:05.999999999Z07:00"},
+	SQLDialectMySQL:     {quoteBacktick, quoteMySQL, [2]string{"FALSE", "TRUE"}, "2006-01-02 15:04:05.999999"},
+	SQLDialectSQLite:    {quoteDouble, quoteSingle, [2]string{"0", "1"}, "2006-01-02 15:04:05.999999999Z07:00"},
+	SQLDialectSQLServer: {quoteBracket, quoteNational, [2]string{"0", "1"}, "2006-01-02T15:04:05.9999999Z07:00"},
+}
+
+// copyEscaper escapes values in the text format of PostgreSQL COPY.
+var copyEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// FprintSQL prints struct or slice to provided io.Writer as INSERT statements into table using provided config.
+// The columns are named by their sql tag option, or else by their label. Each statement inserts
+// Config.SQLBatchSize rows, and identifiers and values are quoted as given by Config.SQLDialect. Numbers and
+// booleans are printed as such, nil values as NULL, times in ISO 8601 format and other values as strings, which
+// are not truncated by Config.MaxColumnWidth or Config.MaxPrintedSliceItems. The table may be qualified by a
+// schema, e.g. "public.users".
+// If config is nil, default config will be used.
+func FprintSQL(w io.Writer, s interface{}, table string, c ...*Config) error {
+	return fprintRendered(w, s, sqlRenderer{}, withSQLTable(table, c...))
+}
+
+// FprintCopy prints struct or slice to provided io.Writer as a PostgreSQL COPY ... FROM stdin statement into table
+// followed by the rows in text format, as read by psql, using provided config. The columns are named by their sql
+// tag option, or else by their label. Nil values are printed as \N, booleans as t and f, times in ISO 8601 format
+// and other values as text, which is not truncated by Config.MaxColumnWidth or Config.MaxPrintedSliceItems. The
+// table may be qualified by a schema, e.g. "public.users".
+// If config is nil, default config will be used.
+func FprintCopy(w io.Writer, s interface{}, table string, c ...*Config) error {
+	return fprintRendered(w, s, copyRenderer{}, withSQLTable(table, c...))
+}
+
+// withSQLTable returns a copy of the config, or an empty config if nil, with Config.SQLTable set to table.
+func withSQLTable(table string, c ...*Config) *Config {
+	conf := Config{}
+	if len(c) > 0 && c[0] != nil {
+		conf = *c[0]
+	}
+	conf.SQLTable = &table
+	return &conf
+}
+
+// sqlTable returns the table of Config.SQLTable quoted by dialect, or an error if it is not set.
+func sqlTable(t *TableModel, dialect sqlDialect) (string, error) {
+	if *t.Config.SQLTable == "" {
+		return "", fmt.Errorf("Missing SQL table: set Config.SQLTable")
+	}
+	return quoteTable(*t.Config.SQLTable, dialect), nil
+}
+
+// sqlRenderer renders tables as INSERT statements into Config.SQLTable.
+type sqlRenderer struct{}
+
+func (sqlRenderer) ContentType() string {
+	return "application/sql"
+}
+
+func (r sqlRenderer) Render(w io.Writer, t *TableModel) error {
+	return r.RenderTables(w, []*TableModel{t})
+}
+
+func (sqlRenderer) RenderTables(w io.Writer, tables []*TableModel) error {
+	statements := []string{}
+	for _, t := range tables {
+		dialect, ok := sqlDialects[*t.Config.SQLDialect]
+		if !ok {
+			return fmt.Errorf("Unknown SQL dialect %d", *t.Config.SQLDialect)
+		}
+		table, err := sqlTable(t, dialect)
+		if err != nil {
+			return err
+		}
+		cp := modelPrinter(t)
+		prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES", table,
+			strings.Join(sqlColumnNames(t.Columns, dialect.quoteIdent), ", "))
+		batchSize := *t.Config.SQLBatchSize
+		if batchSize <= 0 {
+			batchSize = len(t.Rows)
+		}
+		for i := 0; i < len(t.Rows); i += batchSize {
+			rows := []string{}
+			for j := i; j < i+batchSize && j < len(t.Rows); j++ {
+				rows = append(rows, "("+strings.Join(cp.sqlRow(t.Rows[j], dialect), ", ")+")")
+			}
+			if len(rows) == 1 {
+				statements = append(statements, prefix+" "+rows[0]+";")
+			} else {
+				statements = append(statements, prefix+"\n  "+strings.Join(rows, ",\n  ")+";")
+			}
+		}
+	}
+	return writeString(w, strings.Join(statements, "\n"))
+}
+
+// copyRenderer renders tables as PostgreSQL COPY ... FROM stdin statements into Config.SQLTable.
+type copyRenderer struct{}
+
+func (r copyRenderer) Render(w io.Writer, t *TableModel) error {
+	return r.RenderTables(w, []*TableModel{t})
+}
+
+func (copyRenderer) RenderTables(w io.Writer, tables []*TableModel) error {
+	dialect := sqlDialects[SQLDialectPostgres]
+	var b strings.Builder
+	for i, t := range tables {
+		table, err := sqlTable(t, dialect)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "COPY %s (%s) FROM stdin;\n", table,
+			strings.Join(sqlColumnNames(t.Columns, dialect.quoteIdent), ", "))
+		cp := modelPrinter(t)
+		for _, row := range t.Rows {
+			cells := make([]string, len(row.Raw))
+			for k, raw := range row.Raw {
+				cells[k] = cp.copyValue(raw, dialect)
+			}
+			b.WriteString(strings.Join(cells, "\t") + "\n")
+		}
+		b.WriteString(`\.`)
+	}
+	return writeString(w, b.String())
+}
+
+// sqlColumnNames returns the quoted SQL names of the columns.
+func sqlColumnNames(cols []ColumnModel, quoteIdent func(string) string) []string {
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		name := col.Label
+		if col.SQLName != "" {
+			name = col.SQLName
+		}
+		names[i] = quoteIdent(name)
+	}
+	return names
+}
+
+// sqlRow returns the values of row as SQL literals of dialect.
+func (cp *cPrinter) sqlRow(row RowModel, dialect sqlDialect) []string {
+	literals := make([]string, len(row.Raw))
+	for j, raw := range row.Raw {
+		literals[j] = cp.sqlLiteral(raw, dialect)
+	}
+	return literals
+}
+
+// sqlLiteral returns a raw value as an SQL literal of dialect.
+func (cp *cPrinter) sqlLiteral(raw interface{}, dialect sqlDialect) string {
+	if t, ok := rawTime(raw); ok {
+		return dialect.quoteString(t.Format(dialect.timeLayout))
+	}
+	text := cp.sqlText(raw)
+	switch v := cp.typedValue(raw, text).(type) {
+	case nil:
+		return "NULL"
+	case int64, uint64, json.Number:
+		return fmt.Sprint(v)
+	case bool:
+		if v {
+			return dialect.boolValues[1]
+		}
+		return dialect.boolValues[0]
+	}
+	return dialect.quoteString(text)
+}
+
+// copyValue returns a raw value as a value of the text format of PostgreSQL COPY.
+func (cp *cPrinter) copyValue(raw interface{}, dialect sqlDialect) string {
+	if t, ok := rawTime(raw); ok {
+		return t.Format(dialect.timeLayout)
+	}
+	text := cp.sqlText(raw)
+	switch v := cp.typedValue(raw, text).(type) {
+	case nil:
+		return `\N`
+	case int64, uint64, json.Number:
+		return fmt.Sprint(v)
+	case bool:
+		if v {
+			return "t"
+		}
+		return "f"
+	}
+	return copyEscaper.Replace(text)
+}
+
+// sqlText returns a raw value as text, like valueOf but with all the items of slices, and numbers and times as
+// given by rawText.
+func (cp *cPrinter) sqlText(raw interface{}) string {
+	if valuer, ok := raw.(driver.Valuer); ok && !isNilPointer(raw) {
+		value, err := valuer.Value()
+		if err != nil {
+			return cp.valueOf(raw)
+		}
+		raw = value
+	}
+	v := reflect.ValueOf(raw)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return ""
+	}
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return string(v.Bytes())
+	}
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = cp.sqlText(v.Index(i).Interface())
+		}
+		return strings.Join(items, ", ")
+	}
+	return rawText(v.Interface(), cp.valueOf(v.Interface()))
+}
+
+// quoteTable returns a table name qualified by schemas, e.g. public.users, with each part quoted by dialect.
+func quoteTable(table string, dialect sqlDialect) string {
+	parts := strings.Split(table, ".")
+	for i, part := range parts {
+		parts[i] = dialect.quoteIdent(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// quoteDouble quotes an identifier in double quotes.
+func quoteDouble(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// quoteBacktick quotes an identifier in backticks.
+func quoteBacktick(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
+
+// quoteBracket quotes an identifier in brackets.
+func quoteBracket(s string) string {
+	return "[" + strings.ReplaceAll(s, "]", "]]") + "]"
+}
+
+// quoteSingle quotes a string in single quotes.
+func quoteSingle(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// quoteMySQL quotes a string in single quotes, escaping backslashes, which MySQL reads as escape characters.
+func quoteMySQL(s string) string {
+	return quoteSingle(strings.ReplaceAll(s, `\`, `\\`))
+}
+
+// quoteNational quotes a string as a Unicode string literal of SQL Server.
+func quoteNational(s string) string {
+	return "N" + quoteSingle(s)
+}
@@ -0,0 +1,96 @@
+package colprint
+
+import (
+	"bytes"
+	"time"
+)
+
+type dummyFixture struct {
+	ID      int        `colprint:"ID,1,sql=id"`
+	Name    string     `colprint:"Full name,2,sql=full_name"`
+	Admin   bool       `colprint:"Admin,3,sql=is_admin"`
+	Manager *string    `colprint:"Manager,4"`
+	Created time.Time  `colprint:"Created,5,sql=created_at"`
+	Score   float64    `colprint:"Score,6"`
+	Deleted *time.Time `colprint:"Deleted,7,sql=deleted_at"`
+}
+
+func (s *UnitTests) TestFprintSQL() {
+	manager := "o'brien"
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	fixtures := []dummyFixture{
+		{ID: 1, Name: "kari", Admin: true, Manager: &manager, Created: created, Score: 0.125},
+		{ID: 2, Name: `back\slash`, Created: created, Deleted: &created},
+		{ID: 3, Name: "ola", Created: created},
+	}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintSQL(buf, fixtures[:1], "users"))
+	s.Equal(`INSERT INTO "users" ("id", "full_name", "is_admin", "Manager", "created_at", "Score", "deleted_at") `+
+		`VALUES (1, 'kari', TRUE, 'o''brien', '2020-01-02 03:04:05Z', 0.125, NULL);`, buf.String())
+
+	buf.Reset()
+	dialect := SQLDialectMySQL
+	batchSize := 2
+	s.NoError(FprintSQL(buf, fixtures, "app.users",
+		&Config{SQLDialect: &dialect, SQLBatchSize: &batchSize, Columns: []string{"ID", "Full name", "Deleted"}}))
+	s.Equal("INSERT INTO `app`.`users` (`id`, `full_name`, `deleted_at`) VALUES\n"+
+		"  (1, 'kari', NULL),\n"+
+		"  (2, 'back\\\\slash', '2020-01-02 03:04:05');\n"+
+		"INSERT INTO `app`.`users` (`id`, `full_name`, `deleted_at`) VALUES (3, 'ola', NULL);", buf.String())
+
+	buf.Reset()
+	dialect = SQLDialectSQLServer
+	batchSize = 0
+	s.NoError(FprintSQL(buf, fixtures[:2], "users",
+		&Config{SQLDialect: &dialect, SQLBatchSize: &batchSize, Columns: []string{"Full name", "Admin"}}))
+	s.Equal("INSERT INTO [users] ([full_name], [is_admin]) VALUES\n"+
+		"  (N'kari', 1),\n"+
+		"  (N'back\\slash', 0);", buf.String())
+
+	buf.Reset()
+	s.NoError(FprintSQL(buf, []dummyFixture{}, "users"))
+	s.Equal("", buf.String())
+
+	dialect = SQLDialect(42)
+	s.EqualError(FprintSQL(buf, fixtures, "users", &Config{SQLDialect: &dialect}), "Unknown SQL dialect 42")
+	s.Equal(ErrNilValue, FprintSQL(buf, nil, "users"))
+}
+
+func (s *UnitTests) TestFprintCopy() {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600))
+	fixtures := []dummyFixture{
+		{ID: 1, Name: "tab\there", Admin: true, Created: created},
+		{ID: 2, Name: "new\nline \\N", Created: created, Deleted: &created},
+	}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintCopy(buf, fixtures, "public.users", &Config{Columns: []string{"ID", "Full name", "Admin",
+		"Manager", "Deleted"}}))
+	s.Equal("COPY \"public\".\"users\" (\"id\", \"full_name\", \"is_admin\", \"Manager\", \"deleted_at\") FROM stdin;\n"+
+		"1\ttab\\there\tt\t\\N\t\\N\n"+
+		"2\tnew\\nline \\\\N\tf\t\\N\t2020-01-02 03:04:05+01:00\n"+
+		"\\.", buf.String())
+}
+
+func (s *UnitTests) TestFprintSQL_Untruncated() {
+	type event struct {
+		Name string   `colprint:"Name,1"`
+		Tags []string `colprint:"Tags,2"`
+	}
+	width, items := 4, 1
+	conf := &Config{MaxColumnWidth: &width, MaxPrintedSliceItems: &items}
+	events := []event{{Name: "deployment", Tags: []string{"a", "b", "c"}}}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintSQL(buf, events, "events", conf))
+	s.Equal(`INSERT INTO "events" ("Name", "Tags") VALUES ('deployment', 'a, b, c');`, buf.String())
+
+	buf.Reset()
+	s.NoError(FprintCopy(buf, events, "events", conf))
+	s.Equal("COPY \"events\" (\"Name\", \"Tags\") FROM stdin;\ndeployment\ta, b, c\n\\.", buf.String())
+}
+
+func (s *UnitTests) TestQuoteIdentifiers() {
+	s.Equal(`"a""b"`, quoteDouble(`a"b`))
+	s.Equal("`a``b`", quoteBacktick("a`b"))
+	s.Equal("[a]]b]", quoteBracket("a]b"))
+	s.Equal(`"public"."my table"`, quoteTable("public.my table", sqlDialects[SQLDialectPostgres]))
+}
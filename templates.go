@@ -0,0 +1,144 @@
+package colprint
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+)
+
+// TemplateColumn is a column computed by a text/template executed on the printed structs. Besides the
+// functions of text/template, templates can use the formatting functions of colprint:
+//
+//	value    formats a value like colprint formats cells, e.g. {{value .Price}}
+//	join     joins the formatted items of a slice with a separator, e.g. {{join ", " .Tags}}
+//	upper    converts a string to upper case
+//	lower    converts a string to lower case
+//	truncate truncates a string to a number of characters, e.g. {{truncate 10 .Description}}
+//	default  formats a value, or a default value for empty values, e.g. {{default "none" .Owner}}
+type TemplateColumn struct {
+	// Tag is the label and order of the column, in the same format as the colprint tag, e.g. "Address,2".
+	Tag string
+	// Template is the template text, e.g. "{{.Host}}:{{.Port}}".
+	Template string
+	// Replace prints the template columns instead of the tagged fields, registered methods and Config.Computed
+	// columns, as kubectl does for custom columns. The columns of all templates are printed if any of them
+	// replaces.
+	Replace bool
+}
+
+// ParseCustomColumns parses a custom columns specification of the form NAME:TEMPLATE,NAME:TEMPLATE into
+// template columns replacing the tagged fields, ordered as in the specification. Commas inside template actions
+// do not separate columns. Example:
+//
+//	NAME:{{.Name}},ADDR:{{.Host}}:{{.Port}}
+func ParseCustomColumns(spec string) ([]TemplateColumn, error) {
+	cols := []TemplateColumn{}
+	for i, part := range splitOutsideActions(spec) {
+		sep := strings.Index(part, ":")
+		if sep <= 0 {
+			return nil, fmt.Errorf("Invalid custom column %q: expected NAME:TEMPLATE", part)
+		}
+		cols = append(cols, TemplateColumn{Tag: fmt.Sprintf("%s,%d", part[:sep], i), Template: part[sep+1:],
+			Replace: true})
+	}
+	return cols, nil
+}
+
+// splitOutsideActions splits s on commas that are not inside template actions.
+func splitOutsideActions(s string) []string {
+	parts := []string{}
+	depth := 0
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "{{"):
+			depth++
+			i++
+		case strings.HasPrefix(s[i:], "}}") && depth > 0:
+			depth--
+			i++
+		case s[i] == ',' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// templateFuncs returns the functions available to templates, including Config.TemplateFuncs.
+func (cp *cPrinter) templateFuncs() template.FuncMap {
+	funcs := template.FuncMap{
+		"value": cp.valueOf,
+		"join": func(sep string, s interface{}) string {
+			v := reflect.ValueOf(s)
+			if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+				return cp.valueOf(s)
+			}
+			items := make([]string, v.Len())
+			for i := range items {
+				items[i] = cp.valueOf(v.Index(i).Interface())
+			}
+			return strings.Join(items, sep)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"truncate": func(n int, s string) string {
+			if utf8.RuneCountInString(s) <= n {
+				return s
+			}
+			return string([]rune(s)[:n])
+		},
+		"default": func(def interface{}, v interface{}) string {
+			if s := cp.valueOf(v); s != "" {
+				return s
+			}
+			return cp.valueOf(def)
+		},
+	}
+	for name, fn := range cp.config.TemplateFuncs {
+		funcs[name] = fn
+	}
+	return funcs
+}
+
+// appendTemplateColumns appends the columns of Config.Templates to the list of columns, replacing the columns
+// found so far if any template column replaces them. Returns an error if a template cannot be parsed.
+func (cp *cPrinter) appendTemplateColumns() error {
+	if len(cp.config.Templates) == 0 {
+		return nil
+	}
+	for _, tc := range cp.config.Templates {
+		if tc.Replace {
+			cp.cols = columns{}
+			break
+		}
+	}
+	funcs := cp.templateFuncs()
+	for i, tc := range cp.config.Templates {
+		col, err := parseColumnTag(tc.Tag)
+		if err != nil {
+			return &TagError{Field: fmt.Sprintf("Templates[%d]", i), Tag: tc.Tag, Reason: err.Error()}
+		}
+		tmpl, err := template.New(col.label).Funcs(funcs).Option("missingkey=zero").Parse(tc.Template)
+		if err != nil {
+			return fmt.Errorf("Invalid template for column %s: %v", col.label, err)
+		}
+		col.fieldIndex = &[]int{}
+		col.compute = &computer{name: col.label, fn: templateFunc(tmpl)}
+		cp.cols = append(cp.cols, col)
+	}
+	return nil
+}
+
+// templateFunc returns a function executing a template on a struct.
+func templateFunc(tmpl *template.Template) func(v reflect.Value) interface{} {
+	return func(v reflect.Value) interface{} {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, v.Interface()); err != nil {
+			return "<Error:" + err.Error() + ">"
+		}
+		return b.String()
+	}
+}
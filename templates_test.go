@@ -0,0 +1,92 @@
+package colprint
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"text/template"
+)
+
+type dummyServer struct {
+	Name  string   `colprint:"Name,1"`
+	Host  string   `colprint:"-"`
+	Port  int      `colprint:"-"`
+	Load  float64  `colprint:"-"`
+	Tags  []string `colprint:"-"`
+	Owner *string  `colprint:"-"`
+}
+
+func (s *UnitTests) TestFprint_Templates() {
+	servers := []dummyServer{
+		{Name: "web", Host: "10.0.0.1", Port: 80, Load: 0.5, Tags: []string{"a", "b"}},
+		{Name: "db", Host: "10.0.0.2", Port: 5432, Load: 1},
+	}
+	templates := []TemplateColumn{
+		{Tag: "Address,2", Template: "{{.Host}}:{{.Port}}"},
+		{Tag: "Load,3", Template: "{{value .Load}}"},
+		{Tag: "Tags,4", Template: "{{join \"|\" .Tags}}"},
+		{Tag: "Owner,5", Template: "{{default \"none\" .Owner | upper}}"},
+		{Tag: "Short,6", Template: "{{truncate 2 .Name | shout}}"},
+	}
+	funcs := template.FuncMap{"shout": func(s string) string {
+		return s + "!"
+	}}
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, servers, &Config{Templates: templates, TemplateFuncs: funcs}))
+	s.Equal("Name  Address        Load  Tags  Owner  Short\n"+
+		"web   10.0.0.1:80    0.50  a|b   NONE   we!\n"+
+		"db    10.0.0.2:5432  1.00        NONE   db!", buf.String())
+}
+
+func (s *UnitTests) TestFprint_TemplateErrors() {
+	buf := new(bytes.Buffer)
+	s.Error(Fprint(buf, dummyServer{}, &Config{Templates: []TemplateColumn{{Tag: "A", Template: "{{.Name"}}}))
+
+	var tagErr *TagError
+	err := Fprint(buf, dummyServer{}, &Config{Templates: []TemplateColumn{{Tag: "A,b", Template: ""}}})
+	s.True(errors.As(err, &tagErr))
+
+	buf.Reset()
+	s.NoError(Fprint(buf, dummyServer{}, &Config{Templates: []TemplateColumn{{Tag: "A", Template: "{{.Missing}}"}}}))
+	s.True(strings.Contains(buf.String(), "<Error:"))
+}
+
+func (s *UnitTests) TestParseCustomColumns() {
+	cols, err := ParseCustomColumns("NAME:{{.Name}},ADDR:{{.Host}}:{{.Port}},TAGS:{{join \",\" .Tags}}")
+	s.NoError(err)
+	s.Equal([]TemplateColumn{
+		{Tag: "NAME,0", Template: "{{.Name}}", Replace: true},
+		{Tag: "ADDR,1", Template: "{{.Host}}:{{.Port}}", Replace: true},
+		{Tag: "TAGS,2", Template: "{{join \",\" .Tags}}", Replace: true},
+	}, cols)
+
+	_, err = ParseCustomColumns("NAME")
+	s.Error(err)
+	_, err = ParseCustomColumns(":{{.Name}}")
+	s.Error(err)
+}
+
+func (s *UnitTests) TestFprint_CustomColumns() {
+	cols, err := ParseCustomColumns("NAME:{{.Name}},ADDR:{{.Host}}:{{.Port}}")
+	s.NoError(err)
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, dummyServer{Name: "web", Host: "localhost", Port: 8080}, &Config{Templates: cols}))
+	s.Equal("NAME  ADDR\nweb   localhost:8080", buf.String())
+
+	cols, err = ParseCustomColumns("ADDR:{{.Host}}")
+	s.NoError(err)
+	buf.Reset()
+	s.NoError(Fprint(buf, dummyServer{Name: "web", Host: "localhost"}, &Config{Templates: cols,
+		Computed: []ComputedColumn{{Tag: "Port,0", Func: func(item interface{}) interface{} {
+			return item.(dummyServer).Port
+		}}}}))
+	s.Equal("ADDR\nlocalhost", buf.String())
+}
+
+func (s *UnitTests) TestFprint_TemplateDefault() {
+	owner := "ola"
+	templates := []TemplateColumn{{Tag: "Owner,2", Template: "{{default \"none\" .Owner | upper}}"}}
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, []dummyServer{{Name: "web", Owner: &owner}, {Name: "db"}}, &Config{Templates: templates}))
+	s.Equal("Name  Owner\nweb   OLA\ndb    NONE", buf.String())
+}
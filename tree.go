@@ -0,0 +1,73 @@
+package colprint
+
+import (
+	"reflect"
+)
+
+// Glyphs used to draw trees in the first column.
+const (
+	treeBranch     = "├─ "
+	treeLastBranch = "└─ "
+	treeLine       = "│  "
+	treeSpace      = "   "
+	treeCycle      = " (cycle)"
+)
+
+// checkTree returns a TagError if the children field of tree mode does not hold a slice of struct type t.
+func (cp *cPrinter) checkTree(t reflect.Type) error {
+	if cp.tree == nil {
+		return nil
+	}
+	field := t.FieldByIndex(*cp.tree)
+	elem := field.Type.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem != t {
+		return &TagError{Field: field.Name, Tag: field.Tag.Get(TagName), Reason: "tree requires a slice of the printed struct"}
+	}
+	return nil
+}
+
+// addTree adds struct v and its children depth first, with the first column of each child prefixed by tree
+// glyphs. The children of nodes deeper than Config.TreeMaxDepth are hidden, and so are the children of nodes
+// already printed above them, which are marked as cycles.
+func (cp *cPrinter) addTree(v reflect.Value, prefix, cont string, depth int, ancestors map[uintptr]bool) error {
+	children := []reflect.Value{}
+	slice := walk(v, *cp.tree, nil)
+	if slice.IsValid() {
+		for i := 0; i < slice.Len(); i++ {
+			if child := itemValue(slice.Index(i)); child.IsValid() {
+				children = append(children, child)
+			}
+		}
+	}
+
+	marker := ""
+	if len(children) > 0 && ancestors[slice.Pointer()] {
+		marker = treeCycle
+		children = nil
+	} else if len(children) > 0 && *cp.config.TreeMaxDepth >= 0 && depth >= *cp.config.TreeMaxDepth {
+		marker = *cp.config.TreeCollapsedMarker
+		children = nil
+	}
+	if err := cp.addItem(v, prefix, cont, marker); err != nil {
+		return err
+	}
+	if len(children) == 0 {
+		return nil
+	}
+
+	ancestors[slice.Pointer()] = true
+	defer delete(ancestors, slice.Pointer())
+	for i, child := range children {
+		branch, line := treeBranch, treeLine
+		if i == len(children)-1 {
+			branch, line = treeLastBranch, treeSpace
+		}
+		if err := cp.addTree(child, cont+branch, cont+line, depth+1, ancestors); err != nil {
+			return err
+		}
+	}
+	return nil
+}
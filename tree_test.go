@@ -0,0 +1,70 @@
+package colprint
+
+import (
+	"bytes"
+	"errors"
+)
+
+type dummyFile struct {
+	Name     string       `colprint:"Name,1"`
+	Size     int          `colprint:"Size,2"`
+	Children []*dummyFile `colprint:"=>,tree"`
+}
+
+func dummyFileTree() *dummyFile {
+	return &dummyFile{Name: "/", Children: []*dummyFile{
+		{Name: "bin", Children: []*dummyFile{{Name: "ls", Size: 120}, {Name: "cat", Size: 40}}},
+		{Name: "etc", Children: []*dummyFile{{Name: "hosts", Size: 1}}},
+		{Name: "tmp"},
+	}}
+}
+
+func (s *UnitTests) TestSprint_Tree() {
+	val, err := Sprint(dummyFileTree())
+	s.NoError(err)
+	s.Equal("Name         Size\n"+
+		"/            0\n"+
+		"├─ bin       0\n"+
+		"│  ├─ ls     120\n"+
+		"│  └─ cat    40\n"+
+		"├─ etc       0\n"+
+		"│  └─ hosts  1\n"+
+		"└─ tmp       0", val)
+}
+
+func (s *UnitTests) TestFprint_TreeMaxDepth() {
+	depth := 1
+	buf := new(bytes.Buffer)
+	s.NoError(Fprint(buf, dummyFileTree(), &Config{TreeMaxDepth: &depth}))
+	s.Equal("Name        Size\n"+
+		"/           0\n"+
+		"├─ bin [+]  0\n"+
+		"├─ etc [+]  0\n"+
+		"└─ tmp      0", buf.String())
+}
+
+func (s *UnitTests) TestSprint_TreeCycle() {
+	root := &dummyFile{Name: "a"}
+	child := &dummyFile{Name: "b", Children: []*dummyFile{root}}
+	root.Children = []*dummyFile{child}
+	val, err := Sprint([]*dummyFile{root})
+	s.NoError(err)
+	s.Equal("Name             Size\n"+
+		"a                0\n"+
+		"└─ b             0\n"+
+		"   └─ a (cycle)  0", val)
+}
+
+func (s *UnitTests) TestFprint_TreeTagErrors() {
+	type A struct {
+		Name     string      `colprint:"Name"`
+		Children []dummyFile `colprint:"=>,tree"`
+	}
+	type B struct {
+		Name  string `colprint:"Name"`
+		Child *B     `colprint:"=>,tree"`
+	}
+	var tagErr *TagError
+	s.True(errors.As(Fprint(new(bytes.Buffer), A{}), &tagErr))
+	s.True(errors.As(Fprint(new(bytes.Buffer), B{}), &tagErr))
+}
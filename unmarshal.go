@@ -0,0 +1,370 @@
+package colprint
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayout is the layout of time.Time values printed by their String method.
+const timeLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// unmarshaler appends items to a slice of structs, setting their fields from rows of cells matched by label.
+type unmarshaler struct {
+	slice    reflect.Value
+	itemType reflect.Type
+	ptr      bool
+	cols     map[string]column
+}
+
+// Unmarshal parses text printed by Fprint into the slice of structs pointed to by v, appending an item for each
+// row. Cells are matched to fields by the labels of their columns, and the columns are split at the positions of
+// the labels in the header line. Repeated header lines of pages are skipped.
+// Columns not matching a tagged field are ignored, as are computed, template, path and exploded columns. Blank
+// cells leave fields at their zero value, and slices truncated by Config.MaxPrintedSliceItems get only the printed
+// items.
+// If config is nil, default config will be used.
+func Unmarshal(data []byte, v interface{}, c ...*Config) error {
+	u, err := newUnmarshaler(v, c...)
+	if err != nil {
+		return err
+	}
+	text := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	lines := strings.Split(text, "\n")
+	header := strings.TrimRight(lines[0], " ")
+	if header == "" {
+		return nil
+	}
+	headers, starts := splitHeader(header, u.labels())
+	row := 0
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "" && i+1 < len(lines) && strings.TrimRight(lines[i+1], " ") == header {
+			// a page break followed by a repeated header line
+			i++
+			continue
+		}
+		row++
+		if err := u.addRow(row, headers, SplitCells(lines[i], starts)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Scan reads text printed by Fprint from provided io.Reader, and parses it like Unmarshal.
+func Scan(r io.Reader, v interface{}, c ...*Config) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return Unmarshal(data, v, c...)
+}
+
+// UnmarshalCSV parses CSV with a header record of column labels into the slice of structs pointed to by v, like
+// Unmarshal.
+func UnmarshalCSV(data []byte, v interface{}, c ...*Config) error {
+	u, err := newUnmarshaler(v, c...)
+	if err != nil {
+		return err
+	}
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil || len(records) == 0 {
+		return err
+	}
+	for i, record := range records[1:] {
+		if err := u.addRow(i+1, records[0], record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON parses a JSON array of objects keyed by column labels into the slice of structs pointed to by v,
+// like Unmarshal. Values may be strings, numbers, booleans, null, or arrays of those.
+func UnmarshalJSON(data []byte, v interface{}, c ...*Config) error {
+	u, err := newUnmarshaler(v, c...)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	objects := []map[string]interface{}{}
+	if err := dec.Decode(&objects); err != nil {
+		return err
+	}
+	for i, object := range objects {
+		headers := []string{}
+		cells := []string{}
+		for label, val := range object {
+			headers = append(headers, label)
+			cells = append(cells, jsonCell(val))
+		}
+		if err := u.addRow(i+1, headers, cells); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonCell returns a decoded JSON value as the text of a cell, joining arrays like slices are printed.
+func jsonCell(val interface{}) string {
+	switch val := val.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case []interface{}:
+		items := make([]string, len(val))
+		for i, item := range val {
+			items[i] = jsonCell(item)
+		}
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprint(val)
+}
+
+// newUnmarshaler creates an unmarshaler appending to the slice pointed to by v, which must hold structs or
+// pointers to structs.
+func newUnmarshaler(v interface{}, c ...*Config) (*unmarshaler, error) {
+	val := reflect.ValueOf(v)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Slice {
+		return nil, fmt.Errorf("Cannot unmarshal into %T: not a pointer to a slice", v)
+	}
+	u := &unmarshaler{slice: val.Elem(), itemType: val.Elem().Type().Elem(), cols: make(map[string]column)}
+	if u.itemType.Kind() == reflect.Ptr {
+		u.itemType = u.itemType.Elem()
+		u.ptr = true
+	}
+	var conf *Config
+	if len(c) > 0 {
+		conf = c[0]
+	}
+	cp := cPrinter{config: mergeConfig(createDefaultConfig(), conf)}
+	if err := cp.initColumns(u.itemType); err != nil {
+		return nil, err
+	}
+	for _, col := range cp.cols {
+		if _, ok := u.cols[col.label]; ok || col.compute != nil || col.explodeDepth() > 0 {
+			continue
+		}
+		u.cols[col.label] = col
+	}
+	return u, nil
+}
+
+// labels returns the labels of the columns, longest first.
+func (u *unmarshaler) labels() []string {
+	labels := []string{}
+	for label := range u.cols {
+		labels = append(labels, label)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return len(labels[i]) > len(labels[j])
+	})
+	return labels
+}
+
+// addRow appends an item with the fields of the columns labeled by headers set from cells.
+func (u *unmarshaler) addRow(row int, headers, cells []string) error {
+	item := reflect.New(u.itemType).Elem()
+	seen := make(map[string]bool)
+	for i, header := range headers {
+		col, ok := u.cols[header]
+		if !ok || seen[header] || i >= len(cells) {
+			continue
+		}
+		// of columns with the same label, only the first is set
+		seen[header] = true
+		if cells[i] == "" {
+			continue
+		}
+		field, err := settableField(item, *col.fieldIndex)
+		if err != nil {
+			return &FieldAccessError{Field: col.path(u.itemType), Reason: err.Error()}
+		}
+		if err := parseValue(field, cells[i]); err != nil {
+			return &ParseError{Row: row, Column: header, Value: cells[i], Err: err}
+		}
+	}
+	if u.ptr {
+		item = item.Addr()
+	}
+	u.slice.Set(reflect.Append(u.slice, item))
+	return nil
+}
+
+// SplitHeader splits the header line of a printed table into labels, returning the labels and the rune offsets
+// they start at. Labels are separated by at least two spaces.
+func SplitHeader(header string) ([]string, []int) {
+	return splitHeader(header, nil)
+}
+
+// splitHeader splits a header line into labels, returning the labels and the rune offsets they start at. Labels
+// are separated by at least two spaces, unless matching one of the known labels.
+func splitHeader(header string, known []string) ([]string, []int) {
+	runes := []rune(header)
+	labels := []string{}
+	starts := []int{}
+	for p := 0; p < len(runes); {
+		if runes[p] == ' ' {
+			p++
+			continue
+		}
+		end := -1
+		for _, label := range known {
+			e := p + len([]rune(label))
+			if e <= len(runes) && string(runes[p:e]) == label && (e == len(runes) || isColumnGap(runes, e)) {
+				end = e
+				break
+			}
+		}
+		if end < 0 {
+			for end = p; end < len(runes) && !isColumnGap(runes, end); end++ {
+			}
+		}
+		labels = append(labels, string(runes[p:end]))
+		starts = append(starts, p)
+		p = end
+	}
+	return labels, starts
+}
+
+// isColumnGap reports whether the runes at i are the spaces separating two columns.
+func isColumnGap(runes []rune, i int) bool {
+	return i+1 < len(runes) && runes[i] == ' ' && runes[i+1] == ' '
+}
+
+// SplitCells splits a line of a printed table into the cells of the columns starting at the rune offsets starts,
+// as returned by SplitHeader, trimming the padding of the cells.
+func SplitCells(line string, starts []int) []string {
+	runes := []rune(line)
+	cells := make([]string, len(starts))
+	for i, start := range starts {
+		end := len(runes)
+		if i+1 < len(starts) && starts[i+1] < end {
+			end = starts[i+1]
+		}
+		if start < end {
+			cells[i] = strings.TrimRight(string(runes[start:end]), " ")
+		}
+	}
+	return cells
+}
+
+// settableField returns the field at the field index path from struct v, allocating nil pointers on the way.
+// Returns an error if the field cannot be set.
+func settableField(v reflect.Value, path []int) (reflect.Value, error) {
+	for _, x := range path {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, errors.New("nil pointer to unexported struct")
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	if !v.CanSet() {
+		return reflect.Value{}, errors.New("unexported field")
+	}
+	return v, nil
+}
+
+// parseValue sets v from the text of a cell. It is the inverse of valueOf, so slices are split at ", " and times
+// are parsed in the format of their String method, or in RFC 3339 format. Blank text leaves v unchanged.
+func parseValue(v reflect.Value, s string) error {
+	if s == "" {
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return parseValue(v.Elem(), s)
+	}
+	if v.Type() == reflect.TypeOf(time.Time{}) {
+		// drop the monotonic clock reading
+		if i := strings.Index(s, " m="); i >= 0 {
+			s = s[:i]
+		}
+		t, err := time.Parse(timeLayout, s)
+		if err != nil {
+			// times are printed in RFC 3339 format by FprintJSON
+			t, err = time.Parse(time.RFC3339Nano, s)
+		}
+		if err == nil {
+			v.Set(reflect.ValueOf(t))
+		}
+		return err
+	}
+	switch p := v.Addr().Interface().(type) {
+	case sql.Scanner:
+		return p.Scan(s)
+	case encoding.TextUnmarshaler:
+		return p.UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(s))
+			return nil
+		}
+		items := strings.Split(strings.TrimSuffix(s, ",..."), ", ")
+		slice := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := parseValue(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	case reflect.Array:
+		items := strings.Split(strings.TrimSuffix(s, ",..."), ", ")
+		for i := 0; i < len(items) && i < v.Len(); i++ {
+			if err := parseValue(v.Index(i), items[i]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported kind %s", v.Kind())
+	}
+	return nil
+}
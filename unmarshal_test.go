@@ -0,0 +1,88 @@
+package colprint
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+type dummyRecord struct {
+	Name    string         `colprint:"Full name,1"`
+	Count   int            `colprint:"Count,2"`
+	Ratio   float64        `colprint:"Ratio,3"`
+	Enabled bool           `colprint:"Enabled,4"`
+	Tags    []string       `colprint:"Tags,5"`
+	Parent  *string        `colprint:"Parent,6"`
+	Score   sql.NullInt64  `colprint:"Score,7"`
+	Created time.Time      `colprint:"Created,8"`
+	Owner   *dummyOwnerRef `colprint:"=>"`
+}
+
+type dummyOwnerRef struct {
+	Email string `colprint:"Email,9"`
+}
+
+func (s *UnitTests) TestUnmarshal_RoundTrip() {
+	parent := "root"
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	records := []dummyRecord{
+		{Name: "Ola Nordmann", Count: 3, Ratio: 0.5, Enabled: true, Tags: []string{"a", "b"}, Parent: &parent,
+			Score: sql.NullInt64{Int64: 7, Valid: true}, Created: created, Owner: &dummyOwnerRef{Email: "ola@example.com"}},
+		{Name: "Kari", Count: -1},
+	}
+	size := 1
+	for _, c := range []*Config{nil, {PageSize: &size}} {
+		buf := new(bytes.Buffer)
+		s.NoError(Fprint(buf, records, c))
+		parsed := []dummyRecord{}
+		s.NoError(Unmarshal(buf.Bytes(), &parsed))
+		s.Equal(records, parsed)
+	}
+}
+
+func (s *UnitTests) TestUnmarshal_Pointers() {
+	parsed := []*DummyData{}
+	s.NoError(Scan(strings.NewReader("Name  Description  Extra\nfoo   bar          x\n"), &parsed))
+	s.Equal([]*DummyData{{Name: "foo", Description: "bar"}}, parsed)
+
+	s.NoError(Unmarshal([]byte(""), &parsed))
+	s.Len(parsed, 1)
+}
+
+func (s *UnitTests) TestUnmarshal_Errors() {
+	s.Error(Unmarshal([]byte("Name"), []DummyData{}))
+	s.Error(Unmarshal([]byte("Name"), &[]int{}))
+
+	parsed := []dummyRecord{}
+	err := Unmarshal([]byte("Full name  Count\nOla        many"), &parsed)
+	var parseErr *ParseError
+	s.True(errors.As(err, &parseErr))
+	s.Equal(1, parseErr.Row)
+	s.Equal("Count", parseErr.Column)
+	s.Equal("many", parseErr.Value)
+}
+
+func (s *UnitTests) TestUnmarshalCSV() {
+	parsed := []dummyRecord{}
+	s.NoError(UnmarshalCSV([]byte("Full name,Count,Tags\n\"Nordmann, Ola\",3,\"a, b\"\n"), &parsed))
+	s.Equal([]dummyRecord{{Name: "Nordmann, Ola", Count: 3, Tags: []string{"a", "b"}}}, parsed)
+}
+
+func (s *UnitTests) TestUnmarshalJSON() {
+	parsed := []dummyRecord{}
+	s.NoError(UnmarshalJSON([]byte(`[{"Full name": "Ola", "Ratio": 1.5, "Enabled": true, "Tags": ["a"], "Parent": null}]`), &parsed))
+	s.Equal([]dummyRecord{{Name: "Ola", Ratio: 1.5, Enabled: true, Tags: []string{"a"}}}, parsed)
+}
+
+func (s *UnitTests) TestSplitHeader() {
+	labels, starts := splitHeader("First name  Age Group  X", []string{"Age Group"})
+	s.Equal([]string{"First name", "Age Group", "X"}, labels)
+	s.Equal([]int{0, 12, 23}, starts)
+
+	labels, starts = SplitHeader("   Name  Age Group")
+	s.Equal([]string{"Name", "Age Group"}, labels)
+	s.Equal([]int{3, 9}, starts)
+	s.Equal([]string{"~", "Ola", "37"}, SplitCells("~  Ola   37", []int{0, 3, 9}))
+}
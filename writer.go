@@ -0,0 +1,88 @@
+package colprint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"syscall"
+	"unicode/utf8"
+)
+
+// ErrBrokenPipe is wrapped by the errors returned when writing to a closed pipe, e.g. when the output of a CLI
+// is piped to head. CLIs will usually want to exit quietly when errors.Is(err, ErrBrokenPipe).
+var ErrBrokenPipe = errors.New("colprint: broken pipe")
+
+// styleReset is the ANSI escape sequence resetting the style of the following text.
+const styleReset = "\x1b[0m"
+
+// lineWriter writes lines to an io.Writer, separated by newlines. The last line is not terminated.
+type lineWriter struct {
+	w     io.Writer
+	lines int
+	// newPage is called on page breaks instead of writing a blank line, if set
+	newPage func()
+}
+
+// newLineWriter creates a lineWriter writing to w. Page breaks start new pages when writing to a pageWriter.
+func newLineWriter(w io.Writer) *lineWriter {
+	lw := &lineWriter{w: w}
+	if pw, ok := w.(*pageWriter); ok {
+		lw.newPage = pw.newPage
+	}
+	return lw
+}
+
+// pageBreak separates two pages by a blank line, or starts a new page.
+func (lw *lineWriter) pageBreak() error {
+	if lw.newPage != nil {
+		lw.newPage()
+		lw.lines = 0
+		return nil
+	}
+	return lw.writeLine("")
+}
+
+// writeLine writes a line. Returns an error if the writer fails or does not write the whole line.
+func (lw *lineWriter) writeLine(line string) error {
+	if lw.lines > 0 {
+		line = "\n" + line
+	}
+	lw.lines++
+	return writeString(lw.w, line)
+}
+
+// writeString writes s to w. Returns an error if the writer fails or does not write all of s.
+func writeString(w io.Writer, s string) error {
+	n, err := io.WriteString(w, s)
+	if err == nil && n < len(s) {
+		err = io.ErrShortWrite
+	}
+	if errors.Is(err, syscall.EPIPE) {
+		return fmt.Errorf("%w: %w", ErrBrokenPipe, err)
+	}
+	return err
+}
+
+// formatLine joins the values of a line, padding all but the last value to the width of its column.
+func formatLine(vals []string, widths []int) string {
+	return formatStyledLine(vals, widths, nil)
+}
+
+// formatStyledLine formats a line like formatLine, wrapping each value with a non-empty style in styles between
+// the style and a reset escape sequence. Styles do not count towards the width of the values.
+func formatStyledLine(vals []string, widths []int, styles []string) string {
+	var b strings.Builder
+	for i, val := range vals {
+		if i < len(styles) && styles[i] != "" {
+			b.WriteString(styles[i] + val + styleReset)
+		} else {
+			b.WriteString(val)
+		}
+		if i < len(vals)-1 {
+			b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(val)))
+			b.WriteString("  ")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package colprint
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"syscall"
+)
+
+// failingWriter accepts limit bytes, and then fails with err.
+type failingWriter struct {
+	limit int
+	err   error
+	buf   bytes.Buffer
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		n := w.limit - w.buf.Len()
+		w.buf.Write(p[:n])
+		return n, w.err
+	}
+	return w.buf.Write(p)
+}
+
+// shortWriter writes at most one byte per call without returning an error.
+type shortWriter struct{}
+
+func (shortWriter) Write(p []byte) (int, error) {
+	if len(p) > 1 {
+		return 1, nil
+	}
+	return len(p), nil
+}
+
+func (s *UnitTests) TestFprint_WriteError() {
+	persons := []Person{{FirstName: "Ola"}, {FirstName: "Kari"}}
+	writeErr := errors.New("disk full")
+	w := &failingWriter{limit: 10, err: writeErr}
+	s.Equal(writeErr, Fprint(w, persons))
+	s.Equal(10, w.buf.Len())
+}
+
+func (s *UnitTests) TestFprint_ShortWrite() {
+	s.Equal(io.ErrShortWrite, Fprint(shortWriter{}, DummyData{}))
+}
+
+func (s *UnitTests) TestFprint_BrokenPipe() {
+	w := &failingWriter{limit: 0, err: &os.PathError{Op: "write", Path: "/dev/stdout", Err: syscall.EPIPE}}
+	err := Fprint(w, DummyData{})
+	s.True(errors.Is(err, ErrBrokenPipe))
+	s.True(errors.Is(err, syscall.EPIPE))
+}
+
+func (s *UnitTests) TestFprint_WriteErrorFromAllPaths() {
+	writeErr := errors.New("closed")
+	s.Equal(writeErr, Fprint(&failingWriter{err: writeErr}, []map[string]int{{"a": 1}}))
+	s.Equal(writeErr, Fprint(&failingWriter{err: writeErr}, dummyTable{}))
+	s.Equal(writeErr, Fprint(&failingWriter{err: writeErr}, []interface{}{dummyUser{}, dummyGroup{}}))
+
+	mt := MixedTypesSeparate
+	w := &failingWriter{limit: 25, err: writeErr}
+	s.Equal(writeErr, Fprint(w, []interface{}{dummyUser{}, dummyGroup{}}, &Config{MixedTypes: &mt}))
+}
+
+func (s *UnitTests) TestSprint_Alignment() {
+	rows := []map[string]string{
+		{"Name": "Bjørn", "Tags": "a|b"},
+		{"Name": "Ola", "Tags": "c"},
+	}
+	val, err := Sprint(rows)
+	s.NoError(err)
+	s.Equal("Name   Tags\nBjørn  a|b\nOla    c", val)
+}
+
+func (s *UnitTests) TestFormatLine() {
+	s.Equal("a    bb  c", formatLine([]string{"a", "bb", "c"}, []int{3, 2, 5}))
+	s.Equal("", formatLine([]string{}, []int{}))
+}
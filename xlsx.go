@@ -0,0 +1,350 @@
+package colprint
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Styles of the cells of XLSX sheets, by index in the cellXfs of xlsxStyles.
+const (
+	xlsxStyleDefault = 0
+	xlsxStyleHeader  = 1
+	xlsxStyleDate    = 2
+)
+
+// xlsxMaxColumnWidth is the maximum width of the columns of XLSX sheets, in characters.
+const xlsxMaxColumnWidth = 100
+
+// xlsxEpoch is the day before the first day of the 1900 date system of spreadsheets, counting the nonexistent
+// February 29, 1900, so that the serial numbers of dates from March 1, 1900 are right.
+var xlsxEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxStyles has the default style, a bold style for headers and a date style.
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font>` +
+	`<font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill>` +
+	`<fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
+
+// xlsxRels relates the package to its workbook.
+const xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" ` +
+	`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" ` +
+	`Target="xl/workbook.xml"/></Relationships>`
+
+// Sheet is a sheet of an XLSX workbook, named Name and holding the struct or slice Value.
+type Sheet struct {
+	Name  string
+	Value interface{}
+}
+
+// xlsxSheet is a sheet of an XLSX workbook and its table.
+type xlsxSheet struct {
+	name  string
+	table *TableModel
+}
+
+// xlsxFile is a file of the zip archive of an XLSX workbook.
+type xlsxFile struct {
+	name    string
+	content string
+}
+
+// FprintXLSX prints struct or slice to provided io.Writer as an XLSX workbook using provided config, with a sheet
+// named Sheet1. Items of different struct types printed in separate tables are printed in sheets Sheet1, Sheet2
+// and so on. See FprintXLSXSheets for the cells of the sheets.
+// If config is nil, default config will be used.
+func FprintXLSX(w io.Writer, s interface{}, c ...*Config) error {
+	return FprintXLSXSheets(w, []Sheet{{Value: s}}, c...)
+}
+
+// FprintXLSXSheets prints structs or slices to provided io.Writer as an XLSX workbook using provided config, with a
+// sheet per value. Numbers and booleans are printed as typed cells, times as dates, nil values as empty cells and
+// other values as formatted strings. The header row of the column labels is bold and frozen, and the columns are
+// as wide as their widest printed value. Sheets without a name are named SheetN, N being their position in the
+// workbook. Items of different struct types printed in separate tables are printed in sheets named after the
+// sheet followed by their position.
+// If config is nil, default config will be used.
+func FprintXLSXSheets(w io.Writer, sheets []Sheet, c ...*Config) error {
+	xlsxSheets := []xlsxSheet{}
+	names := map[string]bool{}
+	for _, sheet := range sheets {
+		printers, err := load(sheet.Value, c...)
+		if err != nil {
+			return err
+		}
+		for i, cp := range printers {
+			name := sheet.Name
+			switch {
+			case name == "":
+				name = fmt.Sprintf("Sheet%d", len(xlsxSheets)+1)
+			case len(printers) > 1:
+				name = fmt.Sprintf("%s %d", name, i+1)
+			}
+			if err := validateSheetName(name); err != nil {
+				return err
+			}
+			if names[strings.ToLower(name)] {
+				return fmt.Errorf("Duplicate sheet name %s", name)
+			}
+			names[strings.ToLower(name)] = true
+			xlsxSheets = append(xlsxSheets, xlsxSheet{name: name, table: cp.model()})
+		}
+	}
+	return writeXLSX(w, xlsxSheets)
+}
+
+// xlsxRenderer renders tables as an XLSX workbook, with a sheet per table named SheetN.
+type xlsxRenderer struct{}
+
+func (xlsxRenderer) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+func (r xlsxRenderer) Render(w io.Writer, t *TableModel) error {
+	return r.RenderTables(w, []*TableModel{t})
+}
+
+func (xlsxRenderer) RenderTables(w io.Writer, tables []*TableModel) error {
+	sheets := make([]xlsxSheet, len(tables))
+	for i, t := range tables {
+		sheets[i] = xlsxSheet{name: fmt.Sprintf("Sheet%d", i+1), table: t}
+	}
+	return writeXLSX(w, sheets)
+}
+
+// writeXLSX writes a workbook of sheets to w.
+func writeXLSX(w io.Writer, xlsxSheets []xlsxSheet) error {
+	if len(xlsxSheets) == 0 {
+		return fmt.Errorf("Cannot print a workbook without sheets")
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	files := []xlsxFile{
+		{"[Content_Types].xml", xlsxContentTypes(len(xlsxSheets))},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook(xlsxSheets)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels(len(xlsxSheets))},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for i, sheet := range xlsxSheets {
+		files = append(files, xlsxFile{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), xlsxWorksheet(sheet.table)})
+	}
+	for _, file := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate})
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, file.content); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return writeString(w, buf.String())
+}
+
+// validateSheetName returns an error if name cannot be the name of a sheet.
+func validateSheetName(name string) error {
+	if utf8.RuneCountInString(name) > 31 || strings.ContainsAny(name, `[]:*?/\`) ||
+		strings.HasPrefix(name, "'") || strings.HasSuffix(name, "'") {
+		return fmt.Errorf("Invalid sheet name %s: expected at most 31 characters, none of []:*?/\\ and no "+
+			"leading or trailing '", name)
+	}
+	return nil
+}
+
+// xlsxContentTypes returns the content types of a workbook of n sheets.
+func xlsxContentTypes(n int) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ` +
+		`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" `+
+			`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+// xlsxWorkbook returns the workbook listing sheets.
+func xlsxWorkbook(sheets []xlsxSheet) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, sheet := range sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxEscape(sheet.name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+// xlsxWorkbookRels returns the relationships of a workbook of n sheets to its sheets and styles.
+func xlsxWorkbookRels(n int) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" `+
+			`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" `+
+			`Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" `+
+		`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" `+
+		`Target="styles.xml"/>`, n+1)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+// xlsxWorksheet returns the worksheet of table t, with a bold and frozen header row.
+func xlsxWorksheet(t *TableModel) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0">` +
+		`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>` +
+		`<selection pane="bottomLeft"/></sheetView></sheetViews>`)
+	if len(t.Columns) > 0 {
+		b.WriteString(`<cols>`)
+		for i, col := range t.Columns {
+			width := utf8.RuneCountInString(col.Label)
+			for _, row := range t.Rows {
+				if n := utf8.RuneCountInString(row.Values[i]); n > width {
+					width = n
+				}
+			}
+			if width > xlsxMaxColumnWidth {
+				width = xlsxMaxColumnWidth
+			}
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, width+2)
+		}
+		b.WriteString(`</cols>`)
+	}
+
+	b.WriteString(`<sheetData><row r="1">`)
+	for i, col := range t.Columns {
+		writeXLSXString(&b, xlsxCellName(i, 0), col.Label, xlsxStyleHeader)
+	}
+	b.WriteString(`</row>`)
+	cp := modelPrinter(t)
+	for i, row := range t.Rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+2)
+		for j := range t.Columns {
+			cp.writeXLSXCell(&b, xlsxCellName(j, i+1), row.Raw[j], row.Values[j])
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// writeXLSXCell writes the cell named name of a raw value printed as formatted: a number, boolean or date cell if
+// the value is a number, boolean or time, nothing if the value is nil, and otherwise a string cell.
+func (cp *cPrinter) writeXLSXCell(b *strings.Builder, name string, raw interface{}, formatted string) {
+	if t, ok := rawTime(raw); ok {
+		if serial, ok := xlsxSerial(t); ok {
+			fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, name, xlsxStyleDate, serial)
+			return
+		}
+	}
+	switch v := cp.typedValue(raw, formatted).(type) {
+	case nil:
+	case int64, uint64, json.Number:
+		fmt.Fprintf(b, `<c r="%s"><v>%v</v></c>`, name, v)
+	case bool:
+		val := 0
+		if v {
+			val = 1
+		}
+		fmt.Fprintf(b, `<c r="%s" t="b"><v>%d</v></c>`, name, val)
+	default:
+		writeXLSXString(b, name, formatted, xlsxStyleDefault)
+	}
+}
+
+// writeXLSXString writes the string cell named name with the given style.
+func writeXLSXString(b *strings.Builder, name, s string, style int) {
+	fmt.Fprintf(b, `<c r="%s"`, name)
+	if style != xlsxStyleDefault {
+		fmt.Fprintf(b, ` s="%d"`, style)
+	}
+	fmt.Fprintf(b, ` t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, xlsxEscape(s))
+}
+
+// rawTime returns the time of a raw value, if it is a time or a pointer to one.
+func rawTime(raw interface{}) (time.Time, bool) {
+	if valuer, ok := raw.(driver.Valuer); ok && !isNilPointer(raw) {
+		value, err := valuer.Value()
+		if err != nil {
+			return time.Time{}, false
+		}
+		raw = value
+	}
+	v := reflect.ValueOf(raw)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || !v.CanInterface() {
+		return time.Time{}, false
+	}
+	t, ok := v.Interface().(time.Time)
+	return t, ok
+}
+
+// xlsxSerial returns the serial number of a time in the 1900 date system, i.e. the number of days since
+// xlsxEpoch, of the wall clock of the time. Returns false for times before March 1, 1900, which cannot be printed
+// as dates.
+func xlsxSerial(t time.Time) (string, bool) {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	if wall.Before(time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC)) || wall.Year() > 9999 {
+		return "", false
+	}
+	seconds := wall.Unix() - xlsxEpoch.Unix()
+	days := float64(seconds/86400) + (float64(seconds%86400)+float64(wall.Nanosecond())/1e9)/86400
+	return strconv.FormatFloat(days, 'f', -1, 64), true
+}
+
+// xlsxCellName returns the name of the cell at the zero based column and row, e.g. A1 or AB12.
+func xlsxCellName(col, row int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name + strconv.Itoa(row+1)
+}
+
+// xlsxEscape returns s escaped for XML text and attribute values.
+func xlsxEscape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,111 @@
+package colprint
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"io"
+	"time"
+)
+
+// readXLSX returns the files of an XLSX workbook by name.
+func (s *UnitTests) readXLSX(data []byte) map[string]string {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	s.Require().NoError(err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		r, err := f.Open()
+		s.Require().NoError(err)
+		content, err := io.ReadAll(r)
+		s.Require().NoError(err)
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func (s *UnitTests) TestFprintXLSX() {
+	count := 3
+	metrics := []dummyMetric{
+		{Name: "007", Value: 0.375, Count: &count, Up: true, Tags: []string{"a", "b"},
+			Updated: time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC)},
+		{Name: "a & b", Value: 2},
+	}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintXLSX(buf, metrics))
+	files := s.readXLSX(buf.Bytes())
+	s.Contains(files["xl/workbook.xml"], `<sheet name="Sheet1" sheetId="1" r:id="rId1"/>`)
+	s.Contains(files, "xl/styles.xml")
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	s.Contains(sheet, `<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`)
+	s.Contains(sheet, `<col min="1" max="1" width="7" customWidth="1"/>`)
+	s.Contains(sheet, `<col min="6" max="6" width="31" customWidth="1"/>`)
+	s.Contains(sheet, `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c>`)
+	s.Contains(sheet, `<row r="2"><c r="A2" t="inlineStr"><is><t xml:space="preserve">007</t></is></c>`+
+		`<c r="B2"><v>0.375</v></c><c r="C2"><v>3</v></c><c r="D2" t="b"><v>1</v></c>`+
+		`<c r="E2" t="inlineStr"><is><t xml:space="preserve">a, b</t></is></c>`+
+		`<c r="F2" s="2"><v>43832.5</v></c></row>`)
+	s.Contains(sheet, `<row r="3"><c r="A3" t="inlineStr"><is><t xml:space="preserve">a &amp; b</t></is></c>`+
+		`<c r="B3"><v>2</v></c><c r="D3" t="b"><v>0</v></c>`)
+
+	s.Equal(ErrNilValue, FprintXLSX(buf, nil))
+}
+
+func (s *UnitTests) TestFprintXLSXSheets() {
+	buf := new(bytes.Buffer)
+	s.NoError(FprintXLSXSheets(buf, []Sheet{
+		{Name: "Hosts", Value: []dummyHost{{Name: "web"}}},
+		{Value: []dummyProcess{{Name: "nginx"}}},
+	}))
+	files := s.readXLSX(buf.Bytes())
+	s.Contains(files["xl/workbook.xml"], `<sheet name="Hosts" sheetId="1" r:id="rId1"/>`+
+		`<sheet name="Sheet2" sheetId="2" r:id="rId2"/>`)
+	s.Contains(files["xl/_rels/workbook.xml.rels"], `Target="worksheets/sheet2.xml"`)
+	s.Contains(files["[Content_Types].xml"], `PartName="/xl/worksheets/sheet2.xml"`)
+	s.Contains(files["xl/worksheets/sheet2.xml"], `<t xml:space="preserve">nginx</t>`)
+
+	mt := MixedTypesSeparate
+	buf.Reset()
+	s.NoError(FprintXLSXSheets(buf, []Sheet{{Name: "Mixed", Value: []interface{}{dummyHost{}, dummyProcess{}}}},
+		&Config{MixedTypes: &mt}))
+	s.Contains(s.readXLSX(buf.Bytes())["xl/workbook.xml"], `<sheet name="Mixed 1" sheetId="1" r:id="rId1"/>`+
+		`<sheet name="Mixed 2" sheetId="2" r:id="rId2"/>`)
+
+	s.EqualError(FprintXLSXSheets(buf, []Sheet{{Name: "a", Value: []dummyHost{}}, {Name: "A", Value: []dummyHost{}}}),
+		"Duplicate sheet name A")
+	s.Error(FprintXLSXSheets(buf, []Sheet{{Name: "a/b", Value: []dummyHost{}}}))
+	s.Error(FprintXLSXSheets(buf, nil))
+}
+
+func (s *UnitTests) TestFprintXLSX_NilValuer() {
+	type event struct {
+		Name *sql.NullString `colprint:"Name,1"`
+		At   *sql.NullTime   `colprint:"At,2"`
+	}
+	buf := new(bytes.Buffer)
+	at := sql.NullTime{Time: time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC), Valid: true}
+	s.NoError(FprintXLSX(buf, []event{{At: &at}}))
+	s.Contains(s.readXLSX(buf.Bytes())["xl/worksheets/sheet1.xml"], `<row r="2"><c r="B2" s="2"><v>43832.5</v></c></row>`)
+}
+
+func (s *UnitTests) TestXLSXSerial() {
+	serial, ok := xlsxSerial(time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC))
+	s.True(ok)
+	s.Equal("61", serial)
+	serial, ok = xlsxSerial(time.Date(2020, 1, 2, 18, 0, 0, 0, time.FixedZone("CET", 3600)))
+	s.True(ok)
+	s.Equal("43832.75", serial)
+	serial, ok = xlsxSerial(time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC))
+	s.True(ok)
+	s.Equal("2958465", serial)
+	_, ok = xlsxSerial(time.Time{})
+	s.False(ok)
+}
+
+func (s *UnitTests) TestXLSXCellName() {
+	s.Equal("A1", xlsxCellName(0, 0))
+	s.Equal("Z2", xlsxCellName(25, 1))
+	s.Equal("AA3", xlsxCellName(26, 2))
+	s.Equal("AZ1", xlsxCellName(51, 0))
+	s.Equal("BA1", xlsxCellName(52, 0))
+}
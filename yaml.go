@@ -0,0 +1,141 @@
+package colprint
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// yamlPlainValues are strings that YAML parsers read as booleans, null or special floats, and that must be quoted.
+var yamlPlainValues = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true, "y": true, "n": true,
+	"null": true, "~": true, ".inf": true, ".nan": true,
+}
+
+// FprintYAML prints struct or slice to provided io.Writer as a YAML sequence of mappings using provided config.
+// The mappings are keyed by the column labels, in column order. Numbers, booleans and nil values are printed as
+// such, slices as nested sequences, and strings are quoted if they would otherwise be read as another type.
+// Multiline strings are printed as block scalars. Items of different struct types printed in separate tables are
+// printed in the same sequence.
+// If config is nil, default config will be used.
+func FprintYAML(w io.Writer, s interface{}, c ...*Config) error {
+	return fprintRendered(w, s, yamlRenderer{}, c...)
+}
+
+// yamlRenderer renders tables as a YAML sequence of mappings, holding the rows of all the tables.
+type yamlRenderer struct{}
+
+func (yamlRenderer) ContentType() string {
+	return "application/yaml"
+}
+
+func (r yamlRenderer) Render(w io.Writer, t *TableModel) error {
+	return r.RenderTables(w, []*TableModel{t})
+}
+
+func (yamlRenderer) RenderTables(w io.Writer, tables []*TableModel) error {
+	var b strings.Builder
+	for _, t := range tables {
+		cp := modelPrinter(t)
+		for _, row := range t.Rows {
+			if len(t.Columns) == 0 {
+				b.WriteString("- {}\n")
+			}
+			for j, col := range t.Columns {
+				if j == 0 {
+					b.WriteString("- ")
+				} else {
+					b.WriteString("  ")
+				}
+				b.WriteString(yamlString(col.Label) + ":")
+				writeYAMLValue(&b, cp.typedValue(row.Raw[j], row.Values[j]), 4)
+			}
+		}
+	}
+	if b.Len() == 0 {
+		b.WriteString("[]\n")
+	}
+	return writeString(w, strings.TrimSuffix(b.String(), "\n"))
+}
+
+// writeYAMLValue writes a typed value following a key or sequence indicator, with the lines of nested sequences
+// and block scalars indented by indent spaces.
+func writeYAMLValue(b *strings.Builder, v interface{}, indent int) {
+	pad := strings.Repeat(" ", indent)
+	switch v := v.(type) {
+	case nil:
+		b.WriteString(" null\n")
+	case []interface{}:
+		if len(v) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+		b.WriteString("\n")
+		for _, item := range v {
+			b.WriteString(pad + "-")
+			writeYAMLValue(b, item, indent+2)
+		}
+	case string:
+		if !isYAMLBlock(v) {
+			b.WriteString(" " + yamlString(v) + "\n")
+			return
+		}
+		// clip a single final line break, strip none and keep more
+		switch trimmed := strings.TrimRight(v, "\n"); len(v) - len(trimmed) {
+		case 0:
+			b.WriteString(" |-\n")
+		case 1:
+			b.WriteString(" |\n")
+		default:
+			b.WriteString(" |+\n")
+		}
+		for _, line := range strings.Split(strings.TrimRight(v, "\n"), "\n") {
+			if line != "" {
+				b.WriteString(pad + line)
+			}
+			b.WriteString("\n")
+		}
+		for i := len(strings.TrimRight(v, "\n")) + 1; i < len(v); i++ {
+			b.WriteString("\n")
+		}
+	default:
+		b.WriteString(" " + fmt.Sprint(v) + "\n")
+	}
+}
+
+// isYAMLBlock reports whether s is a multiline string that can be printed as a block scalar, i.e. whose lines have
+// no leading spaces on the first line, trailing spaces, or control characters.
+func isYAMLBlock(s string) bool {
+	if !strings.Contains(strings.TrimRight(s, "\n"), "\n") || strings.HasPrefix(s, " ") {
+		return false
+	}
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasSuffix(line, " ") || strings.IndexFunc(line, isControl) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// yamlString returns s as a plain scalar if it would be read back as the same string, and otherwise as a double
+// quoted scalar.
+func yamlString(s string) string {
+	if s == "" || yamlPlainValues[strings.ToLower(s)] || strings.TrimSpace(s) != s ||
+		strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") || strings.Contains(s, ": ") ||
+		strings.Contains(s, " #") || strings.HasSuffix(s, ":") || strings.IndexFunc(s, isControl) >= 0 {
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseInt(s, 0, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// isControl reports whether r is a control character.
+func isControl(r rune) bool {
+	return r < ' ' || r == 0x7f
+}
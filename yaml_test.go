@@ -0,0 +1,69 @@
+package colprint
+
+import (
+	"bytes"
+	"strings"
+)
+
+type dummyManifest struct {
+	Name    string  `colprint:"Name,1"`
+	Version string  `colprint:"Version,2"`
+	Enabled bool    `colprint:"Enabled,3"`
+	Ports   []int   `colprint:"Ports,4"`
+	Script  string  `colprint:"Script,5"`
+	Owner   *string `colprint:"Owner,6"`
+	Size    float64 `colprint:"Size,7"`
+}
+
+func (s *UnitTests) TestFprintYAML() {
+	manifests := []dummyManifest{
+		{Name: "web", Version: "1.10", Enabled: true, Ports: []int{80, 443}, Script: "make\nmake install\n", Size: 1.5},
+		{Name: "yes", Version: "v2", Script: "a: b"},
+	}
+	buf := new(bytes.Buffer)
+	s.NoError(FprintYAML(buf, manifests))
+	s.Equal(`- Name: web
+  Version: "1.10"
+  Enabled: true
+  Ports:
+    - 80
+    - 443
+  Script: |
+    make
+    make install
+  Owner: null
+  Size: 1.5
+- Name: "yes"
+  Version: v2
+  Enabled: false
+  Ports: []
+  Script: "a: b"
+  Owner: null
+  Size: 0`, buf.String())
+
+	buf.Reset()
+	s.NoError(FprintYAML(buf, []dummyManifest{}))
+	s.Equal("[]", buf.String())
+}
+
+func (s *UnitTests) TestWriteYAMLValue_Block() {
+	b := new(bytes.Buffer)
+	for _, v := range []interface{}{"a\n\nb", "a\nb\n\n", " a\nb", []interface{}{[]interface{}{"x"}, "y\nz"}} {
+		var sb strings.Builder
+		writeYAMLValue(&sb, v, 2)
+		b.WriteString(sb.String())
+	}
+	s.Equal(" |-\n  a\n\n  b\n"+
+		" |+\n  a\n  b\n\n"+
+		" \" a\\nb\"\n"+
+		"\n  -\n    - x\n  - |-\n    y\n    z\n", b.String())
+}
+
+func (s *UnitTests) TestYAMLString() {
+	for _, q := range []string{"", "true", "No", "~", "12", "0x1F", "1e3", "- a", "a: b", "a #b", " a", "key:", "*ref", ".inf"} {
+		s.Equal(`"`, yamlString(q)[:1], q)
+	}
+	for _, p := range []string{"web", "a-b", "v1.2", "First name", "http://x", "a:b", "æøå"} {
+		s.Equal(p, yamlString(p))
+	}
+}